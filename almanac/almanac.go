@@ -0,0 +1,221 @@
+// Package almanac generates the daily pages of a nautical almanac: the hourly Greenwich hour
+// angles and declinations of the sun, the moon and the first point of Aries, and the times of
+// twilight, sunrise, sunset, moonrise and moonset for a grid of latitudes.
+//
+// As in the printed almanac, the times are given for the Greenwich meridian, and are local mean
+// times at any other longitude.
+//
+// The hour angles and declinations come from celnav.GetEphemeris, on the accurate sun and moon
+// models, and agree with the printed almanac to about a tenth of a minute of arc.
+package almanac
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sixdouglas/suncalc"
+	"github.com/sixdouglas/suncalc/celnav"
+)
+
+const rad = math.Pi / 180
+
+// Latitudes of the printed almanac, in degrees
+var Latitudes = []float64{72, 70, 68, 66, 64, 62, 60, 58, 56, 54, 52, 50, 45, 40, 35, 30, 20, 10, 0, -10, -20, -30, -35, -40, -45, -50, -52, -54, -56, -58, -60}
+
+// Hour holds the ephemerides at a whole hour, angles in radians
+type Hour struct {
+	Time time.Time
+
+	SunGHA         float64
+	SunDeclination float64
+
+	MoonGHA                float64
+	MoonDeclination        float64
+	MoonHorizontalParallax float64
+
+	AriesGHA float64
+}
+
+// Visibility of a body that neither rises nor sets during the day
+type Visibility int
+
+const (
+	RisesAndSets Visibility = iota
+	AlwaysUp
+	AlwaysDown
+)
+
+// Events of a latitude, zero when they do not occur during the day
+type Events struct {
+	Latitude float64
+
+	NauticalDawn time.Time
+	CivilDawn    time.Time
+	Sunrise      time.Time
+	Sunset       time.Time
+	CivilDusk    time.Time
+	NauticalDusk time.Time
+	Sun          Visibility
+
+	Moonrise time.Time
+	Moonset  time.Time
+	Moon     Visibility
+}
+
+type Page struct {
+	Date  time.Time
+	Hours []Hour
+
+	// Semi-diameters at noon, in radians
+	SunSemiDiameter  float64
+	MoonSemiDiameter float64
+
+	Events []Events
+}
+
+// generates the page of the UT day of the date, for the given latitudes in degrees
+// (the Latitudes of the printed almanac when nil)
+func GetPage(date time.Time, latitudes []float64) Page {
+	if latitudes == nil {
+		latitudes = Latitudes
+	}
+
+	day := date.UTC()
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	noon := day.Add(12 * time.Hour)
+	page := Page{
+		Date:             day,
+		SunSemiDiameter:  celnav.GetEphemeris(celnav.Sun, noon).SemiDiameter,
+		MoonSemiDiameter: celnav.GetEphemeris(celnav.Moon, noon).SemiDiameter,
+	}
+
+	for h := 0; h < 24; h++ {
+		t := day.Add(time.Duration(h) * time.Hour)
+		sun := celnav.GetEphemeris(celnav.Sun, t)
+		moon := celnav.GetEphemeris(celnav.Moon, t)
+		page.Hours = append(page.Hours, Hour{t, sun.GHA, sun.Declination, moon.GHA, moon.Declination, moon.HorizontalParallax, suncalc.GetSiderealTime(t)})
+	}
+
+	for _, lat := range latitudes {
+		obs := suncalc.Observer{Latitude: lat, Longitude: 0, Location: time.UTC}
+		times := suncalc.GetTimesWithObserver(noon, obs)
+		events := Events{
+			Latitude:     lat,
+			NauticalDawn: times[suncalc.NauticalDawn].Value,
+			CivilDawn:    times[suncalc.Dawn].Value,
+			Sunrise:      times[suncalc.Sunrise].Value,
+			Sunset:       times[suncalc.Sunset].Value,
+			CivilDusk:    times[suncalc.Dusk].Value,
+			NauticalDusk: times[suncalc.NauticalDusk].Value,
+		}
+		if events.Sunrise.IsZero() {
+			events.Sun = AlwaysDown
+			if suncalc.GetPosition(times[suncalc.SolarNoon].Value, lat, 0).Altitude > 0 {
+				events.Sun = AlwaysUp
+			}
+		}
+
+		moon := suncalc.GetMoonHorizonTimes(day, obs)
+		events.Moonrise, events.Moonset = moon.Rise, moon.Set
+		switch {
+		case moon.AlwaysUp:
+			events.Moon = AlwaysUp
+		case moon.AlwaysDown:
+			events.Moon = AlwaysDown
+		}
+		page.Events = append(page.Events, events)
+	}
+	return page
+}
+
+// writes the page as plain text tables, like the printed almanac: angles in degrees and minutes,
+// times in hours and minutes. Body continuously above the horizon: □□□□, below: ■■■■,
+// twilight lasting all night: ////
+func (p Page) WriteText(w io.Writer) error {
+	var b bytes.Buffer
+	line := func(format string, a ...interface{}) {
+		b.WriteString(strings.TrimRight(fmt.Sprintf(format, a...), " "))
+		b.WriteByte('\n')
+	}
+
+	line("%s (UT)", p.Date.Format("2006 January 2, Monday"))
+	line("")
+	line("%-3s  %-9s %-8s   %-9s %-8s %-5s   %-9s", "", "SUN", "", "MOON", "", "", "ARIES")
+	line("%-3s  %-9s %-8s   %-9s %-8s %-5s   %-9s", "UT", "GHA", "Dec", "GHA", "Dec", "HP", "GHA")
+	for _, h := range p.Hours {
+		line("%02d   %s %s   %s %s %5.1f   %s", h.Time.Hour(),
+			formatGHA(h.SunGHA), formatDeclination(h.SunDeclination),
+			formatGHA(h.MoonGHA), formatDeclination(h.MoonDeclination), h.MoonHorizontalParallax/rad*60,
+			formatGHA(h.AriesGHA))
+	}
+	line("     SD %4.1f                SD %4.1f", p.SunSemiDiameter/rad*60, p.MoonSemiDiameter/rad*60)
+	line("")
+
+	line("      Twilight                      Twilight")
+	line("Lat   Naut.  Civil  Sunr.    Suns.  Civil  Naut.    Moonr. Moons.")
+	for _, e := range p.Events {
+		line("%-5s %-6s %-6s %-6s   %-6s %-6s %-6s   %-6s %-6s", formatLatitude(e.Latitude),
+			formatTwilight(e.NauticalDawn, e.Sun), formatTwilight(e.CivilDawn, e.Sun), formatTime(e.Sunrise, e.Sun),
+			formatTime(e.Sunset, e.Sun), formatTwilight(e.CivilDusk, e.Sun), formatTwilight(e.NauticalDusk, e.Sun),
+			formatTime(e.Moonrise, e.Moon), formatTime(e.Moonset, e.Moon))
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// formats an angle between 0 and 360 degrees as "ddd mm.m"
+func formatGHA(angle float64) string {
+	deg, min := degreesMinutes(angle)
+	if deg == 360 {
+		deg = 0
+	}
+	return fmt.Sprintf("%3d %04.1f", deg, min)
+}
+
+// formats a declination as "Ndd mm.m"
+func formatDeclination(angle float64) string {
+	hemisphere := "N"
+	if angle < 0 {
+		hemisphere, angle = "S", -angle
+	}
+	deg, min := degreesMinutes(angle)
+	return fmt.Sprintf("%s%02d %04.1f", hemisphere, deg, min)
+}
+
+// returns the degrees and minutes of a positive angle, the minutes rounded to a tenth
+func degreesMinutes(angle float64) (int, float64) {
+	tenths := int(math.Round(angle / rad * 600))
+	return tenths / 600, float64(tenths%600) / 10
+}
+
+func formatLatitude(lat float64) string {
+	if lat < 0 {
+		return fmt.Sprintf("S %2.0f", -lat)
+	}
+	return fmt.Sprintf("N %2.0f", lat)
+}
+
+func formatTime(t time.Time, visibility Visibility) string {
+	switch {
+	case !t.IsZero():
+		return t.Add(30 * time.Second).Format("15:04")
+	case visibility == AlwaysUp:
+		return "□□□□"
+	case visibility == AlwaysDown:
+		return "■■■■"
+	}
+	return "--:--"
+}
+
+// a twilight that does not occur while the sun rises and sets lasts all night
+func formatTwilight(t time.Time, sun Visibility) string {
+	if t.IsZero() && sun == RisesAndSets {
+		return "////"
+	}
+	return formatTime(t, sun)
+}
@@ -0,0 +1,125 @@
+package almanac
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sixdouglas/suncalc"
+	"github.com/sixdouglas/suncalc/celnav"
+)
+
+func TestGetPageHours(t *testing.T) {
+	page := GetPage(time.Date(2021, 6, 21, 15, 0, 0, 0, time.FixedZone("", -3*60*60)), nil)
+
+	if want := time.Date(2021, 6, 21, 0, 0, 0, 0, time.UTC); !page.Date.Equal(want) {
+		t.Errorf("GetPage() date = %v, want the UT day %v", page.Date, want)
+	}
+	if len(page.Hours) != 24 {
+		t.Fatalf("GetPage() hours = %d, want 24", len(page.Hours))
+	}
+
+	noon := page.Hours[12]
+	sun := celnav.GetEphemeris(celnav.Sun, noon.Time)
+	if noon.SunGHA != sun.GHA || noon.SunDeclination != sun.Declination || noon.AriesGHA != suncalc.GetSiderealTime(noon.Time) {
+		t.Errorf("GetPage() noon = %+v, want the sun %+v", noon, sun)
+	}
+
+	// the sun moves by about 15° an hour, the moon by about 14° 19'
+	for i := 1; i < 24; i++ {
+		sunStep := math.Mod(page.Hours[i].SunGHA-page.Hours[i-1].SunGHA+2*math.Pi, 2*math.Pi) / rad
+		moonStep := math.Mod(page.Hours[i].MoonGHA-page.Hours[i-1].MoonGHA+2*math.Pi, 2*math.Pi) / rad
+		if math.Abs(sunStep-15) > 0.01 || math.Abs(moonStep-14.3) > 0.2 {
+			t.Errorf("GetPage() hour %d moves the sun by %v° and the moon by %v°", i, sunStep, moonStep)
+		}
+	}
+}
+
+func TestGetPageEvents(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		latitude float64
+		want     Visibility
+	}{
+		{time.Date(2021, 6, 21, 0, 0, 0, 0, time.UTC), 72, AlwaysUp},
+		{time.Date(2021, 6, 21, 0, 0, 0, 0, time.UTC), -60, RisesAndSets},
+		{time.Date(2021, 12, 21, 0, 0, 0, 0, time.UTC), 72, AlwaysDown},
+		{time.Date(2021, 12, 21, 0, 0, 0, 0, time.UTC), 52, RisesAndSets},
+	}
+	for _, tt := range tests {
+		page := GetPage(tt.date, []float64{tt.latitude})
+		e := page.Events[0]
+		if e.Sun != tt.want {
+			t.Errorf("GetPage(%v) at %v sun = %v, want %v", tt.date, tt.latitude, e.Sun, tt.want)
+		}
+
+		times := suncalc.GetTimes(tt.date.Add(12*time.Hour), tt.latitude, 0)
+		if !e.Sunrise.Equal(times[suncalc.Sunrise].Value) || !e.NauticalDusk.Equal(times[suncalc.NauticalDusk].Value) {
+			t.Errorf("GetPage(%v) at %v = %v, %v, want %v, %v", tt.date, tt.latitude, e.Sunrise, e.NauticalDusk, times[suncalc.Sunrise].Value, times[suncalc.NauticalDusk].Value)
+		}
+	}
+}
+
+func TestPageWriteText(t *testing.T) {
+	var b bytes.Buffer
+	if err := GetPage(time.Date(2021, 6, 21, 0, 0, 0, 0, time.UTC), []float64{72, 60, 0}).WriteText(&b); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	text := b.String()
+
+	for _, want := range []string{
+		"2021 June 21, Monday (UT)\n",
+		"\n00   179 33.7 N23 26.2 ",
+		"\nN 72  □□□□   □□□□   □□□□     □□□□   □□□□   □□□□ ",
+		"\nN 60  ////   00:49  02:36    21:28  23:14  //// ",
+		"\nN  0  05:09  05:36  05:58    18:06  18:28  18:54 ",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("WriteText() = %s, want it to contain %q", text, want)
+		}
+	}
+}
+
+func TestPageReference(t *testing.T) {
+	// published apparent places at 0h TD, 59 s before 0h UT (Meeus, examples 47.a and 25.b), moved to 0h UT with
+	// the hourly motions and converted to hour angles with the apparent sidereal time (Meeus 12.4 and 22.a)
+	var b bytes.Buffer
+	if err := GetPage(time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC), []float64{}).WriteText(&b); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	if want := "    65 44.9 N13 45.9  59.5   200 26.8\n"; !strings.Contains(b.String(), "\n00   179 47.3 N08 41.8"+want) {
+		t.Errorf("WriteText() = %s, want the moon and Aries %q", b.String(), want)
+	}
+
+	// the sun is given by the model of chapter 25, within two tenths of a minute of VSOP87
+	arcmin := rad / 60
+	sun := GetPage(time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC), []float64{}).Hours[0]
+	if gha := (183 + 25.6/60) * rad; math.Abs(sun.SunGHA-gha) > 0.2*arcmin {
+		t.Errorf("GetPage() sun GHA = %v, want %v", sun.SunGHA/rad, gha/rad)
+	}
+	if dec := -(7 + 47.0/60) * rad; math.Abs(sun.SunDeclination-dec) > 0.2*arcmin {
+		t.Errorf("GetPage() sun declination = %v, want %v", sun.SunDeclination/rad, dec/rad)
+	}
+	if aries := (21 + 48.3/60) * rad; math.Abs(sun.AriesGHA-aries) > 0.05*arcmin {
+		t.Errorf("GetPage() Aries GHA = %v, want %v", sun.AriesGHA/rad, aries/rad)
+	}
+}
+
+func TestFormatAngles(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{formatGHA(359.99999 * rad), "  0 00.0"},
+		{formatGHA(12.5 * rad), " 12 30.0"},
+		{formatDeclination(-12.5 * rad), "S12 30.0"},
+		{formatDeclination(5.0 / 60 * rad), "N00 05.0"},
+		{formatLatitude(-35), "S 35"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package suncalc
+
+import (
+	"math"
+	"time"
+)
+
+// TrackPoint is a timestamped position of a vehicle, like an aircraft or a vessel
+type TrackPoint struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+
+	// Height in meters, lowering the horizon like Observer.Height
+	Height float64
+
+	OnGround bool
+}
+
+// Track is a list of points sorted by time
+type Track []TrackPoint
+
+// returns the position at the given time, linearly interpolated between the points of the track;
+// OnGround is the one of the previous point. Before the start or after the end, the first or last point is returned
+func (tr Track) At(t time.Time) TrackPoint {
+	if len(tr) == 0 {
+		return TrackPoint{Time: t}
+	}
+	if !t.After(tr[0].Time) {
+		p := tr[0]
+		p.Time = t
+		return p
+	}
+	for i := 1; i < len(tr); i++ {
+		p0, p1 := tr[i-1], tr[i]
+		if t.After(p1.Time) {
+			continue
+		}
+		if t.Equal(p1.Time) {
+			return p1
+		}
+		f := 0.0
+		if span := p1.Time.Sub(p0.Time); span > 0 {
+			f = float64(t.Sub(p0.Time)) / float64(span)
+		}
+		// the longitude follows the shortest way, across the antimeridian if needed
+		dLng := math.Remainder(p1.Longitude-p0.Longitude, 360)
+		return TrackPoint{
+			Time:      t,
+			Latitude:  p0.Latitude + f*(p1.Latitude-p0.Latitude),
+			Longitude: math.Remainder(p0.Longitude+f*dLng, 360),
+			Height:    p0.Height + f*(p1.Height-p0.Height),
+			OnGround:  p0.OnGround,
+		}
+	}
+	p := tr[len(tr)-1]
+	p.Time = t
+	return p
+}
+
+// NightDefinition defines when it is night for a regulator
+type NightDefinition struct {
+	Name string
+
+	// Sun altitude in degrees, relative to the horizon of the vehicle, below which it is night
+	Angle float64
+
+	// When not zero, the night starts this delay after the sun goes below Angle, and ends this delay
+	// before the sun goes above it
+	Offset time.Duration
+}
+
+var (
+	// FAA (14 CFR 1.1) and EASA night: from the end of the evening civil twilight to the beginning of the morning civil twilight
+	CivilNight = NightDefinition{"civil twilight", -6, 0}
+
+	// FAA night for the landings of the passenger carrying currency (14 CFR 61.57(b)):
+	// from one hour after sunset to one hour before sunrise
+	HourAfterSunsetNight = NightDefinition{"one hour after sunset", -0.833, time.Hour}
+)
+
+// tells whether it is night at the given position, the horizon being lowered by the height
+func (def NightDefinition) IsNight(p TrackPoint) bool {
+	below := func(t time.Time) bool {
+		pos := GetPosition(t, p.Latitude, p.Longitude)
+		return pos.Altitude < (def.Angle+observerAngle(p.Height))*rad
+	}
+	if def.Offset == 0 {
+		return below(p.Time)
+	}
+	return below(p.Time.Add(-def.Offset)) && below(p.Time.Add(def.Offset))
+}
+
+type NightFlight struct {
+	// Intervals of night along the track
+	Intervals []Interval
+
+	// Total duration of the intervals
+	Duration time.Duration
+
+	// Times of the landings at night, when the track goes from airborne to on ground
+	Landings []time.Time
+}
+
+// sampling of the track looking for the night
+const nightStep = time.Minute
+
+// calculates the night time and the night landings of a flight, from its whole track
+// (time on ground included, as it is for block times)
+func GetNightFlight(track Track, def NightDefinition) NightFlight {
+	var result NightFlight
+	if len(track) == 0 {
+		return result
+	}
+
+	result.Intervals = findIntervals(track[0].Time, track[len(track)-1].Time, nightStep, func(t time.Time) bool {
+		return def.IsNight(track.At(t))
+	})
+	for _, i := range result.Intervals {
+		result.Duration += i.Duration()
+	}
+
+	for i := 1; i < len(track); i++ {
+		if !track[i-1].OnGround && track[i].OnGround && def.IsNight(track[i]) {
+			result.Landings = append(result.Landings, track[i].Time)
+		}
+	}
+	return result
+}
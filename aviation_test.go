@@ -0,0 +1,88 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTrackAt(t *testing.T) {
+	start := time.Date(2021, 3, 21, 0, 0, 0, 0, time.UTC)
+	track := Track{
+		{Time: start, Latitude: 10, Longitude: 179, Height: 0, OnGround: true},
+		{Time: start.Add(time.Hour), Latitude: 20, Longitude: -179, Height: 1000},
+	}
+
+	got := track.At(start.Add(30 * time.Minute))
+	if got.Latitude != 15 || math.Abs(math.Abs(got.Longitude)-180) > 1e-9 || got.Height != 500 || !got.OnGround {
+		t.Errorf("At() = %+v, want the middle of the leg across the antimeridian", got)
+	}
+	got = track.At(start.Add(45 * time.Minute))
+	if math.Abs(got.Longitude+179.5) > 1e-9 {
+		t.Errorf("At() longitude = %v, want -179.5", got.Longitude)
+	}
+	if got := track.At(start.Add(2 * time.Hour)); got.Latitude != 20 || got.OnGround {
+		t.Errorf("At() after the end = %+v, want the last point", got)
+	}
+}
+
+func TestGetNightFlight(t *testing.T) {
+	start := time.Date(2021, 3, 21, 12, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	today := GetTimes(start, 51.5, -0.1)
+	tomorrow := GetTimes(end, 51.5, -0.1)
+	ground := Track{{Time: start, Latitude: 51.5, Longitude: -0.1}, {Time: end, Latitude: 51.5, Longitude: -0.1}}
+
+	// the night flight follows the center of the sun, GetTimes its upper edge 0.833° higher
+	const tolerance = 2 * time.Second
+	near := func(a, b time.Time) bool {
+		d := a.Sub(b)
+		return d < tolerance && d > -tolerance
+	}
+
+	tests := []struct {
+		name       string
+		def        NightDefinition
+		start, end time.Time
+	}{
+		{"civil twilight", CivilNight, today[Dusk].Value, tomorrow[Dawn].Value},
+		{"one hour after sunset", HourAfterSunsetNight, today[Sunset].Value.Add(time.Hour), tomorrow[Sunrise].Value.Add(-time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetNightFlight(ground, tt.def)
+			if len(got.Intervals) != 1 || !near(got.Intervals[0].Start, tt.start) || !near(got.Intervals[0].End, tt.end) {
+				t.Fatalf("GetNightFlight() = %v, want %v to %v", got.Intervals, tt.start, tt.end)
+			}
+			if got.Duration != got.Intervals[0].Duration() {
+				t.Errorf("GetNightFlight() duration = %v, want %v", got.Duration, got.Intervals[0].Duration())
+			}
+		})
+	}
+
+	// the horizon is lower in flight: the night is shorter
+	high := Track{{Time: start, Latitude: 51.5, Longitude: -0.1, Height: 10000}, {Time: end, Latitude: 51.5, Longitude: -0.1, Height: 10000}}
+	if low, got := GetNightFlight(ground, CivilNight).Duration, GetNightFlight(high, CivilNight).Duration; got >= low-10*time.Minute {
+		t.Errorf("GetNightFlight() at 10000 m = %v, want shorter than %v at ground level", got, low)
+	}
+}
+
+func TestGetNightFlightLandings(t *testing.T) {
+	day := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	track := Track{
+		{Time: day.Add(10 * time.Hour), Latitude: 48.7, Longitude: 2.4, OnGround: true},
+		{Time: day.Add(10*time.Hour + 10*time.Minute), Latitude: 48.7, Longitude: 2.4},
+		{Time: day.Add(11 * time.Hour), Latitude: 45.7, Longitude: 5.1, OnGround: true}, // day landing
+		{Time: day.Add(21 * time.Hour), Latitude: 45.7, Longitude: 5.1, OnGround: true},
+		{Time: day.Add(21*time.Hour + 10*time.Minute), Latitude: 45.7, Longitude: 5.1},
+		{Time: day.Add(22 * time.Hour), Latitude: 48.7, Longitude: 2.4, OnGround: true}, // night landing
+	}
+
+	got := GetNightFlight(track, CivilNight)
+	if len(got.Landings) != 1 || !got.Landings[0].Equal(day.Add(22*time.Hour)) {
+		t.Errorf("GetNightFlight() landings = %v, want the one at 22:00", got.Landings)
+	}
+	if len(got.Intervals) != 1 || !got.Intervals[0].End.Equal(day.Add(22*time.Hour)) {
+		t.Errorf("GetNightFlight() = %v, want a single night until the end of the track", got.Intervals)
+	}
+}
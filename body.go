@@ -0,0 +1,140 @@
+package suncalc
+
+import (
+	"math"
+	"time"
+)
+
+// Body is a celestial body whose position and rise, transit and set times can be calculated
+type Body interface {
+	// Geocentric equatorial coordinates at the date, the distance being zero for a star
+	Coordinates(date time.Time) EquatorialCoordinates
+
+	// Apparent radius of the disk, in radians
+	Radius(date time.Time) float64
+
+	// Horizontal parallax, in radians
+	Parallax(date time.Time) float64
+}
+
+const (
+	moonRadiusKm = 1737.4
+
+	// refraction at the horizon, used for the rise and set times of all the bodies
+	horizonRefraction = 0.5667 * rad
+)
+
+type sunBody struct{}
+
+type moonBody struct{}
+
+var (
+	// the sun, with the same model as GetPosition, and the moon, with the accurate model of GetAccurateMoonCoordinates
+	Sun  Body = sunBody{}
+	Moon Body = moonBody{}
+)
+
+func (sunBody) Coordinates(date time.Time) EquatorialCoordinates {
+	return GetSunCoordinates(date)
+}
+
+func (b sunBody) Radius(date time.Time) float64 {
+	return math.Asin(sunRadiusKm / b.Coordinates(date).Distance)
+}
+
+func (b sunBody) Parallax(date time.Time) float64 {
+	return math.Asin(earthRadiusKm / b.Coordinates(date).Distance)
+}
+
+func (moonBody) Coordinates(date time.Time) EquatorialCoordinates {
+	return GetAccurateMoonCoordinates(date)
+}
+
+func (b moonBody) Radius(date time.Time) float64 {
+	return math.Asin(moonRadiusKm / b.Coordinates(date).Distance)
+}
+
+func (b moonBody) Parallax(date time.Time) float64 {
+	return math.Asin(earthRadiusKm / b.Coordinates(date).Distance)
+}
+
+// equatorial radii of the planets, in km
+var planetRadiiKm = []float64{2439.7, 6051.8, 3396.2, 71492, 60268, 25559, 24764}
+
+func (p Planet) Coordinates(date time.Time) EquatorialCoordinates {
+	d := toDays(date)
+	c := planetCoordinates(p, d)
+	ra, dec := trueEquinox(d).equatorial(c.lon, c.lat)
+	return EquatorialCoordinates{ra, dec, c.delta * astronomicalUnit}
+}
+
+func (p Planet) Radius(date time.Time) float64 {
+	return math.Asin(planetRadiiKm[p] / p.Coordinates(date).Distance)
+}
+
+func (p Planet) Parallax(date time.Time) float64 {
+	return math.Asin(earthRadiusKm / p.Coordinates(date).Distance)
+}
+
+type BodyPosition struct {
+	Azimuth  float64
+	Altitude float64
+}
+
+// returns the azimuth, the hour angle and the altitude of the center of the body seen from the surface of the Earth,
+// without refraction
+func topocentric(date time.Time, lat float64, lng float64, body Body) (float64, float64, float64) {
+	lw := rad * -lng
+	phi := rad * lat
+	c := body.Coordinates(date)
+	H := siderealTime(toDays(date), lw) - c.RightAscension
+	h := altitude(H, phi, c.Declination)
+	return azimuth(H, phi, c.Declination), H, h - body.Parallax(date)*math.Cos(h)
+}
+
+// calculates the position of the center of a body for a given date and latitude/longitude,
+// the altitude being corrected for the parallax and the refraction
+func GetBodyPosition(date time.Time, lat float64, lng float64, body Body) BodyPosition {
+	az, _, h := topocentric(date, lat, lng, body)
+	return BodyPosition{az, h + astroRefraction(h)}
+}
+
+type BodyTimes struct {
+	Rise       time.Time
+	Transit    time.Time
+	Set        time.Time
+	AlwaysUp   bool
+	AlwaysDown bool
+}
+
+// calculates the rise, transit and set times of a body for the observer's local day: the instants
+// the upper edge of its disk appears over and disappears under the observer's horizon profile if any,
+// and the instant it crosses the meridian, north of the zenith or south of it. Zero when they do not occur during the day
+func GetBodyTimes(date time.Time, obs Observer, body Body) BodyTimes {
+	horizon := horizonTimes(date, obs, func(t time.Time) float64 {
+		az, _, h := topocentric(t, obs.Latitude, obs.Longitude, body)
+		return h + body.Radius(t) + horizonRefraction - obs.horizonAltitude(az)
+	})
+	result := BodyTimes{
+		Rise:       horizon.Rise,
+		Set:        horizon.Set,
+		AlwaysUp:   horizon.AlwaysUp,
+		AlwaysDown: horizon.AlwaysDown,
+	}
+
+	// the sine of the hour angle rises through zero at the upper transit
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, obs.Location)
+	for _, c := range findCrossings(start, start.AddDate(0, 0, 1), time.Hour, func(t time.Time) float64 {
+		_, H, _ := topocentric(t, obs.Latitude, obs.Longitude, body)
+		return math.Sin(H)
+	}) {
+		if c.rising {
+			result.Transit = c.time
+		}
+	}
+	return result
+}
+
+func (t BodyTimes) horizonTimes() HorizonTimes {
+	return HorizonTimes{t.Rise, t.Set, t.AlwaysUp, t.AlwaysDown}
+}
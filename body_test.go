@@ -0,0 +1,81 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetBodyTimes(t *testing.T) {
+	date := time.Date(2013, 3, 5, 0, 0, 0, 0, time.UTC)
+	obs := Observer{50.5, 30.5, 0, time.UTC, nil}
+
+	sun := GetBodyTimes(date, obs, Sun)
+	times := GetTimesWithObserver(date, obs)
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"sunrise", sun.Rise, times[Sunrise].Value},
+		{"sun transit", sun.Transit, times[SolarNoon].Value},
+		{"sunset", sun.Set, times[Sunset].Value},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the same solver, sampled from midnight or from noon
+			if diff := tt.got.Sub(tt.want); diff < -time.Second || diff > time.Second {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBodyTimesMoon(t *testing.T) {
+	date := time.Date(2013, 3, 5, 0, 0, 0, 0, time.UTC)
+	obs := Observer{50.5, 30.5, 0, time.UTC, nil}
+	moon := GetBodyTimes(date, obs, Moon)
+	// the moon rises after midnight, on the next day
+	if !moon.Rise.IsZero() || moon.Set.IsZero() || moon.Transit.IsZero() {
+		t.Fatalf("GetBodyTimes(Moon) = %+v, want a transit and a set only", moon)
+	}
+	next := GetBodyTimes(date.AddDate(0, 0, 1), obs, Moon)
+
+	// the geocentric altitude of the moon at its rise and set is 0.7275 times its parallax minus 34' (Meeus 15)
+	for _, date := range []time.Time{moon.Set, next.Rise} {
+		want := 0.7275*Moon.Parallax(date) - 0.5667*rad
+		c := Moon.Coordinates(date)
+		got := altitude(siderealTime(toDays(date), -obs.Longitude*rad)-c.RightAscension, obs.Latitude*rad, c.Declination)
+		if math.Abs(got-want) > 0.01*rad {
+			t.Errorf("altitude at %v = %v, want %v", date, got/rad, want/rad)
+		}
+	}
+}
+
+func TestGetBodyTimesPolar(t *testing.T) {
+	obs := Observer{78.22, 15.65, 0, time.UTC, nil}
+	if got := GetBodyTimes(time.Date(2020, 6, 21, 0, 0, 0, 0, time.UTC), obs, Sun); !got.AlwaysUp || !got.Rise.IsZero() || got.Transit.IsZero() {
+		t.Errorf("GetBodyTimes() in June = %+v, want AlwaysUp with a transit", got)
+	}
+	if got := GetBodyTimes(time.Date(2020, 12, 21, 0, 0, 0, 0, time.UTC), obs, Sun); !got.AlwaysDown || !got.Set.IsZero() {
+		t.Errorf("GetBodyTimes() in December = %+v, want AlwaysDown", got)
+	}
+}
+
+func TestGetBodyPosition(t *testing.T) {
+	date := time.Date(2013, 3, 5, 10, 0, 0, 0, time.UTC)
+	sun := GetBodyPosition(date, 50.5, 30.5, Sun)
+	want := GetPosition(date, 50.5, 30.5)
+	if math.Abs(sun.Azimuth-want.Azimuth) > 1e-3 || math.Abs(sun.Altitude-(want.Altitude+astroRefraction(want.Altitude))) > 1e-3 {
+		t.Errorf("GetBodyPosition(Sun) = %+v, want %+v", sun, want)
+	}
+
+	moon := GetBodyPosition(date, 50.5, 30.5, Moon)
+	c := Moon.Coordinates(date)
+	geocentric := altitude(siderealTime(toDays(date), -30.5*rad)-c.RightAscension, 50.5*rad, c.Declination)
+	// the moon is lowered by its parallax, about one degree
+	if diff := geocentric + astroRefraction(geocentric) - moon.Altitude; diff < 0.5*rad || diff > 1*rad {
+		t.Errorf("GetBodyPosition(Moon).Altitude = %v, want about one degree below %v", moon.Altitude, geocentric)
+	}
+}
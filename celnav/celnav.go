@@ -0,0 +1,230 @@
+// Package celnav reduces sextant sights of the sun and the moon to lines of position, and
+// finds the position of a vessel from several of them.
+//
+// Angles are in radians, latitudes and longitudes in degrees (east positive), and distances
+// in nautical miles, one minute of arc of a great circle.
+package celnav
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/sixdouglas/suncalc"
+)
+
+const rad = math.Pi / 180
+
+// one nautical mile, in radians of a great circle
+const nauticalMile = rad / 60
+
+const (
+	earthRadius = 6378.14 // equatorial, km
+	sunRadius   = 696000  // km
+	moonRadius  = 1737.4  // km
+)
+
+type Body int
+
+const (
+	Sun Body = iota
+	Moon
+)
+
+// Limb is the edge of the body brought to the horizon with the sextant
+type Limb int
+
+const (
+	Center Limb = iota
+	LowerLimb
+	UpperLimb
+)
+
+// Ephemeris of a body, as given by a nautical almanac
+type Ephemeris struct {
+	// Greenwich hour angle, between 0 and 2 PI, and declination
+	GHA         float64
+	Declination float64
+
+	SemiDiameter       float64
+	HorizontalParallax float64
+}
+
+// calculates the ephemeris of the body for a given date, from the accurate models of the sun and the moon,
+// to about a tenth of a minute of arc like a nautical almanac
+func GetEphemeris(body Body, date time.Time) Ephemeris {
+	c, radius := suncalc.GetAccurateSunCoordinates(date), float64(sunRadius)
+	if body == Moon {
+		c, radius = suncalc.GetAccurateMoonCoordinates(date), moonRadius
+	}
+	return Ephemeris{
+		GHA:                normalize(suncalc.GetSiderealTime(date) - c.RightAscension),
+		Declination:        c.Declination,
+		SemiDiameter:       math.Asin(radius / c.Distance),
+		HorizontalParallax: math.Asin(earthRadius / c.Distance),
+	}
+}
+
+type Position struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// calculates the altitude (Hc) and the true azimuth (Zn, clockwise from north) of the center of the body
+// seen from a position, without refraction nor parallax
+func Reduce(e Ephemeris, p Position) (hc float64, zn float64) {
+	phi := p.Latitude * rad
+	lha := e.GHA + p.Longitude*rad
+	dec := e.Declination
+
+	hc = math.Asin(math.Sin(phi)*math.Sin(dec) + math.Cos(phi)*math.Cos(dec)*math.Cos(lha))
+	zn = normalize(math.Atan2(-math.Cos(dec)*math.Sin(lha), math.Cos(phi)*math.Sin(dec)-math.Sin(phi)*math.Cos(dec)*math.Cos(lha)))
+	return hc, zn
+}
+
+// Sight is an altitude of a body measured with a sextant
+type Sight struct {
+	Body Body
+	Limb Limb
+	Time time.Time
+
+	// Altitude read on the sextant (Hs)
+	SextantAltitude float64
+
+	// Index error of the sextant, positive when it reads too high (on the arc)
+	IndexError float64
+
+	// Height of eye above the sea, in meters
+	Height float64
+
+	// Temperature in °C and pressure in hPa, used to correct the refraction
+	// when the pressure is set, standard conditions (10 °C, 1010 hPa) otherwise
+	Temperature float64
+	Pressure    float64
+}
+
+// Corrections to add to the sextant altitude to get the observed altitude
+type Corrections struct {
+	Index        float64
+	Dip          float64
+	Refraction   float64
+	Parallax     float64
+	SemiDiameter float64
+}
+
+func (c Corrections) Total() float64 {
+	return c.Index + c.Dip + c.Refraction + c.Parallax + c.SemiDiameter
+}
+
+// dip of the sea horizon seen from a height of eye in meters, in radians: 1.76' √h of the Nautical Almanac,
+// with the terrestrial refraction over the sea, smaller than the dip of suncalc.HorizonDip used for the sun times
+func dip(height float64) float64 {
+	return 1.76 * math.Sqrt(height) / 60 * rad
+}
+
+// calculates the corrections of the sight
+func (s Sight) Corrections() Corrections {
+	e := GetEphemeris(s.Body, s.Time)
+
+	c := Corrections{Index: -s.IndexError, Dip: -dip(s.Height)}
+	apparent := s.SextantAltitude + c.Index + c.Dip
+
+	// Bennett's formula, in minutes of arc, apparent altitude in degrees
+	h := apparent / rad
+	c.Refraction = -rad / 60 / math.Tan((h+7.31/(h+4.4))*rad)
+	if s.Pressure != 0 {
+		c.Refraction *= s.Pressure / 1010 * 283 / (273 + s.Temperature)
+	}
+
+	c.Parallax = math.Asin(math.Sin(e.HorizontalParallax) * math.Cos(apparent+c.Refraction))
+	switch s.Limb {
+	case LowerLimb:
+		c.SemiDiameter = e.SemiDiameter
+	case UpperLimb:
+		c.SemiDiameter = -e.SemiDiameter
+	}
+	return c
+}
+
+// calculates the observed altitude (Ho) of the center of the body, seen from the center of the Earth
+func (s Sight) ObservedAltitude() float64 {
+	return s.SextantAltitude + s.Corrections().Total()
+}
+
+// LineOfPosition is the line, perpendicular to the azimuth of the body, on which the vessel was at the time of the sight
+type LineOfPosition struct {
+	Sight    Sight
+	Assumed  Position
+	Azimuth  float64
+	Computed float64
+
+	// Observed minus computed altitude, towards the body when positive
+	Intercept float64
+}
+
+// reduces the sight from the assumed position
+func NewLineOfPosition(s Sight, assumed Position) LineOfPosition {
+	hc, zn := Reduce(GetEphemeris(s.Body, s.Time), assumed)
+	return LineOfPosition{s, assumed, zn, hc, s.ObservedAltitude() - hc}
+}
+
+// Motion of the vessel between the sights
+type Motion struct {
+	// True course, clockwise from north
+	Course float64
+
+	// Speed in knots
+	Speed float64
+}
+
+// returns the position reached from p after sailing during d (dead reckoning, mid-latitude sailing)
+func (m Motion) From(p Position, d time.Duration) Position {
+	distance := m.Speed * d.Hours()
+	dLat := distance * math.Cos(m.Course) / 60
+	mid := (p.Latitude + dLat/2) * rad
+	return Position{p.Latitude + dLat, p.Longitude + distance*math.Sin(m.Course)/60/math.Cos(mid)}
+}
+
+var ErrNoFix = errors.New("celnav: the lines of position do not cross")
+
+// number of times the sights are reduced again from the last fix
+const fixIterations = 5
+
+// calculates the position at a given time from sights taken at different times, the lines of position
+// being advanced with the motion of the vessel (running fix). The position is the least squares
+// intersection of the lines, reduced again from the estimate until it converges
+func Fix(sights []Sight, estimate Position, at time.Time, motion Motion) (Position, error) {
+	fix := estimate
+	for i := 0; i < fixIterations; i++ {
+		// in a plane tangent at the fix, in nautical miles towards east (x) and north (y)
+		var sxx, sxy, syy, sxc, syc float64
+		for _, s := range sights {
+			assumed := Motion{motion.Course + math.Pi, motion.Speed}.From(fix, at.Sub(s.Time))
+			lop := NewLineOfPosition(s, assumed)
+
+			// the line advanced to the fix time: n . p = c, n pointing to the body
+			nx, ny := math.Sin(lop.Azimuth), math.Cos(lop.Azimuth)
+			c := lop.Intercept / nauticalMile
+			sxx, sxy, syy = sxx+nx*nx, sxy+nx*ny, syy+ny*ny
+			sxc, syc = sxc+nx*c, syc+ny*c
+		}
+
+		det := sxx*syy - sxy*sxy
+		if len(sights) < 2 || det < 1e-6 {
+			return Position{}, ErrNoFix
+		}
+		x := (syy*sxc - sxy*syc) / det
+		y := (sxx*syc - sxy*sxc) / det
+
+		fix = Position{fix.Latitude + y/60, fix.Longitude + x/60/math.Cos(fix.Latitude*rad)}
+	}
+	return fix, nil
+}
+
+func normalize(angle float64) float64 {
+	angle = math.Mod(angle, 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
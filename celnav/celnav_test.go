@@ -0,0 +1,166 @@
+package celnav
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sixdouglas/suncalc"
+)
+
+func TestReduce(t *testing.T) {
+	date := time.Date(2021, 7, 14, 15, 30, 0, 0, time.UTC)
+	p := Position{Latitude: 47.3, Longitude: -4.2}
+
+	// the ephemeris uses the accurate sun, GetPosition agrees with it to a few seconds of arc
+	hc, zn := Reduce(GetEphemeris(Sun, date), p)
+	pos := suncalc.GetPosition(date, p.Latitude, p.Longitude)
+	if arcsec := rad / 3600; math.Abs(hc-pos.Altitude) > 10*arcsec || math.Abs(zn-(pos.Azimuth+math.Pi)) > 10*arcsec {
+		t.Errorf("Reduce() = %v, %v, want %v, %v", hc/rad, zn/rad, pos.Altitude/rad, (pos.Azimuth+math.Pi)/rad)
+	}
+}
+
+func TestGetEphemeris(t *testing.T) {
+	date := time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC) // the Earth at perihelion
+
+	sun := GetEphemeris(Sun, date)
+	if sd := sun.SemiDiameter / rad * 60; math.Abs(sd-16.27) > 0.02 {
+		t.Errorf("GetEphemeris(Sun) semi-diameter = %v', want 16.27'", sd)
+	}
+	if hp := sun.HorizontalParallax / rad * 60; math.Abs(hp-0.15) > 0.01 {
+		t.Errorf("GetEphemeris(Sun) horizontal parallax = %v', want 0.15'", hp)
+	}
+
+	// the semi-diameter of the moon is about 0.2725 its horizontal parallax
+	moon := GetEphemeris(Moon, date)
+	if hp := moon.HorizontalParallax / rad * 60; hp < 53.9 || hp > 61.5 {
+		t.Errorf("GetEphemeris(Moon) horizontal parallax = %v', want between 54' and 61.5'", hp)
+	}
+	if ratio := moon.SemiDiameter / moon.HorizontalParallax; math.Abs(ratio-0.2725) > 0.001 {
+		t.Errorf("GetEphemeris(Moon) semi-diameter / parallax = %v, want 0.2725", ratio)
+	}
+}
+
+func TestGetEphemerisReference(t *testing.T) {
+	// published apparent places at 0h TD, about one minute before 0h UT: Meeus, example 25.b (VSOP87)
+	// for the sun and example 47.a for the moon, with a horizontal parallax of 0.991990°
+	arcmin := rad / 60
+	tests := []struct {
+		name     string
+		body     Body
+		date     time.Time
+		ra, dec  float64
+		parallax float64
+	}{
+		{"sun", Sun, time.Date(1992, 10, 12, 23, 59, 1, 0, time.UTC), 198.378178, -7.783871, 8.794 / 3600 / 0.99760775},
+		{"moon", Moon, time.Date(1992, 4, 11, 23, 59, 1, 0, time.UTC), 134.688470, 13.768368, 0.991990},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := GetEphemeris(tt.body, tt.date)
+			gha := normalize(suncalc.GetSiderealTime(tt.date) - tt.ra*rad)
+			if d := math.Remainder(e.GHA-gha, 2*math.Pi); math.Abs(d) > 0.2*arcmin {
+				t.Errorf("GetEphemeris() GHA = %v, want %v", e.GHA/rad, gha/rad)
+			}
+			if math.Abs(e.Declination-tt.dec*rad) > 0.1*arcmin {
+				t.Errorf("GetEphemeris() declination = %v, want %v", e.Declination/rad, tt.dec)
+			}
+			if math.Abs(e.HorizontalParallax-tt.parallax*rad) > 0.1*arcmin {
+				t.Errorf("GetEphemeris() horizontal parallax = %v, want %v", e.HorizontalParallax/rad, tt.parallax)
+			}
+		})
+	}
+}
+
+func TestSightCorrections(t *testing.T) {
+	s := Sight{
+		Body:            Sun,
+		Limb:            LowerLimb,
+		Time:            time.Date(2021, 1, 3, 12, 0, 0, 0, time.UTC),
+		SextantAltitude: 30 * rad,
+		IndexError:      2 * rad / 60,
+		Height:          10,
+	}
+
+	arcmin := rad / 60
+	got := s.Corrections()
+	want := Corrections{Index: -2 * arcmin, Dip: -5.57 * arcmin, Refraction: -1.72 * arcmin, Parallax: 0.13 * arcmin, SemiDiameter: 16.27 * arcmin}
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"index", got.Index, want.Index},
+		{"dip", got.Dip, want.Dip},
+		{"refraction", got.Refraction, want.Refraction},
+		{"parallax", got.Parallax, want.Parallax},
+		{"semi-diameter", got.SemiDiameter, want.SemiDiameter},
+	} {
+		if math.Abs(c.got-c.want) > 0.02*arcmin {
+			t.Errorf("Corrections() %s = %v', want %v'", c.name, c.got/arcmin, c.want/arcmin)
+		}
+	}
+	if ho := s.ObservedAltitude(); math.Abs(ho-s.SextantAltitude-got.Total()) > 1e-12 {
+		t.Errorf("ObservedAltitude() = %v, want %v", ho, s.SextantAltitude+got.Total())
+	}
+
+	// colder and denser air refracts more
+	s.Temperature, s.Pressure = -10, 1030
+	if cold := s.Corrections().Refraction; cold >= got.Refraction {
+		t.Errorf("Corrections() refraction = %v', want more than %v'", cold/arcmin, got.Refraction/arcmin)
+	}
+}
+
+// returns the sextant altitude giving the true altitude of the body seen from the position
+func sextantAltitude(s Sight, p Position) float64 {
+	hc, _ := Reduce(GetEphemeris(s.Body, s.Time), p)
+	s.SextantAltitude = hc
+	for i := 0; i < 10; i++ {
+		s.SextantAltitude += hc - s.ObservedAltitude()
+	}
+	return s.SextantAltitude
+}
+
+func TestFix(t *testing.T) {
+	start := time.Date(2021, 5, 10, 8, 0, 0, 0, time.UTC)
+	motion := Motion{Course: 45 * rad, Speed: 12}
+	origin := Position{Latitude: 38, Longitude: -20}
+
+	var sights []Sight
+	for _, h := range []float64{0, 3, 6.5} {
+		date := start.Add(time.Duration(h * float64(time.Hour)))
+		s := Sight{Body: Sun, Limb: LowerLimb, Time: date, Height: 3}
+		s.SextantAltitude = sextantAltitude(s, motion.From(origin, date.Sub(start)))
+		sights = append(sights, s)
+	}
+	moon := Sight{Body: Moon, Limb: UpperLimb, Time: start.Add(time.Hour), Height: 3}
+	moon.SextantAltitude = sextantAltitude(moon, motion.From(origin, time.Hour))
+
+	at := sights[2].Time
+	want := motion.From(origin, at.Sub(start))
+	got, err := Fix(append(sights, moon), Position{want.Latitude + 0.4, want.Longitude - 0.5}, at, motion)
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	if dLat, dLng := (got.Latitude-want.Latitude)*60, (got.Longitude-want.Longitude)*60*math.Cos(want.Latitude*rad); math.Hypot(dLat, dLng) > 0.1 {
+		t.Errorf("Fix() = %+v, want %+v", got, want)
+	}
+
+	if _, err := Fix(sights[:1], want, at, motion); err != ErrNoFix {
+		t.Errorf("Fix() error = %v, want %v", err, ErrNoFix)
+	}
+}
+
+func TestLineOfPosition(t *testing.T) {
+	date := time.Date(2021, 5, 10, 14, 0, 0, 0, time.UTC)
+	vessel := Position{Latitude: 38, Longitude: -20}
+	s := Sight{Body: Sun, Limb: LowerLimb, Time: date}
+	s.SextantAltitude = sextantAltitude(s, vessel)
+
+	// from an assumed position 10 miles away from the body, the intercept is 10 miles towards it
+	_, zn := Reduce(GetEphemeris(Sun, date), vessel)
+	assumed := Motion{Course: zn + math.Pi, Speed: 10}.From(vessel, time.Hour)
+	lop := NewLineOfPosition(s, assumed)
+	if math.Abs(lop.Intercept/nauticalMile-10) > 0.05 || math.Abs(lop.Azimuth-zn) > 0.01 {
+		t.Errorf("NewLineOfPosition() = %v nm towards %v, want 10 nm towards %v", lop.Intercept/nauticalMile, lop.Azimuth/rad, zn/rad)
+	}
+}
@@ -0,0 +1,115 @@
+// Package coord converts the positions of celestial bodies between the ecliptic, equatorial,
+// horizontal and galactic coordinate systems (chapters 13 and 14 of "Astronomical Algorithms").
+//
+// Angles are in radians, latitudes and longitudes of the observer in degrees (east positive), and
+// the azimuth is measured from the south towards the west, like the positions returned by suncalc.
+package coord
+
+import (
+	"math"
+	"time"
+
+	"github.com/sixdouglas/suncalc"
+	"github.com/sixdouglas/suncalc/internal/sphere"
+)
+
+const rad = math.Pi / 180
+
+// Ecliptic coordinates, referred to the ecliptic and the equinox of a date
+type Ecliptic struct {
+	Longitude float64
+	Latitude  float64
+}
+
+// Equatorial coordinates, referred to the equator and the equinox of a date
+type Equatorial struct {
+	RightAscension float64
+	Declination    float64
+}
+
+// Horizontal coordinates of an observer
+type Horizontal struct {
+	Azimuth  float64
+	Altitude float64
+}
+
+// Galactic coordinates, referred to the galactic plane of the IAU and the equinox J2000
+type Galactic struct {
+	Longitude float64
+	Latitude  float64
+}
+
+// north galactic pole and galactic longitude of the north celestial pole, at J2000
+var (
+	galacticPoleRA  = 192.85948 * rad
+	galacticPoleDec = 27.12825 * rad
+	celestialPoleL  = 122.93192 * rad
+)
+
+func normalize(a float64) float64 {
+	a = math.Mod(a, 2*math.Pi)
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+	return a
+}
+
+// converts ecliptic coordinates to equatorial ones for an obliquity of the ecliptic, such as
+// the one returned by suncalc.GetObliquity
+func EclipticToEquatorial(c Ecliptic, obliquity float64) Equatorial {
+	ra, dec := sphere.EclipticToEquatorial(c.Longitude, c.Latitude, obliquity)
+	return Equatorial{normalize(ra), dec}
+}
+
+// converts equatorial coordinates to ecliptic ones for an obliquity of the ecliptic
+func EquatorialToEcliptic(c Equatorial, obliquity float64) Ecliptic {
+	lon, lat := sphere.EquatorialToEcliptic(c.RightAscension, c.Declination, obliquity)
+	return Ecliptic{normalize(lon), lat}
+}
+
+// calculates the local hour angle of a right ascension for a given date and longitude, between 0 and 2 PI,
+// from the apparent sidereal time of suncalc.GetSiderealTime
+func HourAngle(date time.Time, lng float64, ra float64) float64 {
+	return normalize(suncalc.GetSiderealTime(date) + lng*rad - ra)
+}
+
+// converts equatorial coordinates to horizontal ones for a given date and latitude/longitude,
+// without refraction, like the positions of suncalc
+func EquatorialToHorizontal(c Equatorial, date time.Time, lat float64, lng float64) Horizontal {
+	H, phi := HourAngle(date, lng, c.RightAscension), lat*rad
+	return Horizontal{sphere.Azimuth(H, phi, c.Declination), sphere.Altitude(H, phi, c.Declination)}
+}
+
+// converts horizontal coordinates to equatorial ones for a given date and latitude/longitude
+func HorizontalToEquatorial(c Horizontal, date time.Time, lat float64, lng float64) Equatorial {
+	H, dec := sphere.HorizontalToEquatorial(c.Azimuth, c.Altitude, lat*rad)
+	return Equatorial{normalize(suncalc.GetSiderealTime(date) + lng*rad - H), dec}
+}
+
+// calculates the parallactic angle of a body for a given date and latitude/longitude: the angle between
+// the direction of the zenith and the one of the north celestial pole, seen from the body, as in suncalc.GetMoonPosition
+func ParallacticAngle(c Equatorial, date time.Time, lat float64, lng float64) float64 {
+	return sphere.ParallacticAngle(HourAngle(date, lng, c.RightAscension), lat*rad, c.Declination)
+}
+
+// converts equatorial coordinates referred to the equinox J2000 to galactic ones
+func EquatorialToGalactic(c Equatorial) Galactic {
+	sinG, cosG := math.Sincos(galacticPoleDec)
+	sinA, cosA := math.Sincos(c.RightAscension - galacticPoleRA)
+	sinD, cosD := math.Sincos(c.Declination)
+	return Galactic{
+		Longitude: normalize(celestialPoleL - math.Atan2(cosD*sinA, sinD*cosG-cosD*sinG*cosA)),
+		Latitude:  math.Asin(sinD*sinG + cosD*cosG*cosA),
+	}
+}
+
+// converts galactic coordinates to equatorial ones referred to the equinox J2000
+func GalacticToEquatorial(c Galactic) Equatorial {
+	sinG, cosG := math.Sincos(galacticPoleDec)
+	sinL, cosL := math.Sincos(celestialPoleL - c.Longitude)
+	sinB, cosB := math.Sincos(c.Latitude)
+	return Equatorial{
+		RightAscension: normalize(galacticPoleRA + math.Atan2(cosB*sinL, sinB*cosG-cosB*sinG*cosL)),
+		Declination:    math.Asin(sinB*sinG + cosB*cosG*cosL),
+	}
+}
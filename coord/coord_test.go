@@ -0,0 +1,97 @@
+package coord
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sixdouglas/suncalc"
+)
+
+func TestEclipticEquatorial(t *testing.T) {
+	// Meeus, example 13.a: Pollux, with the mean obliquity of J2000
+	obliquity := 23.4392911 * rad
+	ecl := EquatorialToEcliptic(Equatorial{116.328942 * rad, 28.026183 * rad}, obliquity)
+	if math.Abs(ecl.Longitude/rad-113.215630) > 0.000001 || math.Abs(ecl.Latitude/rad-6.684170) > 0.000001 {
+		t.Errorf("EquatorialToEcliptic() = %v, %v, want 113.215630, 6.684170", ecl.Longitude/rad, ecl.Latitude/rad)
+	}
+	eq := EclipticToEquatorial(ecl, obliquity)
+	if math.Abs(eq.RightAscension/rad-116.328942) > 0.000001 || math.Abs(eq.Declination/rad-28.026183) > 0.000001 {
+		t.Errorf("EclipticToEquatorial() = %v, %v, want 116.328942, 28.026183", eq.RightAscension/rad, eq.Declination/rad)
+	}
+}
+
+func TestEquatorialToHorizontal(t *testing.T) {
+	// Meeus, example 13.b: Venus seen from the US Naval Observatory in Washington,
+	// the azimuth 68.0337° being measured from the south
+	date := time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC)
+	lat, lng := 38+55.0/60+17.0/3600, -(77 + 3.0/60 + 56.0/3600)
+	h := EquatorialToHorizontal(Equatorial{347.3193375 * rad, -6.719892 * rad}, date, lat, lng)
+	if math.Abs(h.Azimuth/rad-68.0337) > 0.001 || math.Abs(h.Altitude/rad-15.1249) > 0.001 {
+		t.Errorf("EquatorialToHorizontal() = %v, %v, want 68.0337, 15.1249", h.Azimuth/rad, h.Altitude/rad)
+	}
+
+	// the same position as GetPosition for the sun
+	sun := suncalc.GetSunCoordinates(date)
+	pos := suncalc.GetPosition(date, lat, lng)
+	h = EquatorialToHorizontal(Equatorial{sun.RightAscension, sun.Declination}, date, lat, lng)
+	if math.Abs(h.Azimuth-pos.Azimuth) > 1e-9 || math.Abs(h.Altitude-pos.Altitude) > 1e-9 {
+		t.Errorf("EquatorialToHorizontal() of the sun = %+v, want %+v", h, pos)
+	}
+}
+
+func TestParallacticAngle(t *testing.T) {
+	date := time.Date(2013, 3, 5, 0, 0, 0, 0, time.UTC)
+	lat, lng := 50.5, 30.5
+	moon := suncalc.GetMoonCoordinates(date)
+	want := suncalc.GetMoonPosition(date, lat, lng).ParallacticAngle
+	if got := ParallacticAngle(Equatorial{moon.RightAscension, moon.Declination}, date, lat, lng); math.Abs(got-want) > 1e-9 {
+		t.Errorf("ParallacticAngle() = %v, want %v", got, want)
+	}
+}
+
+func TestGalactic(t *testing.T) {
+	tests := []struct {
+		name       string
+		eq         Equatorial
+		l, b       float64
+		lTolerance float64
+	}{
+		{"galactic center", Equatorial{266.40499 * rad, -28.93617 * rad}, 0, 0, 0.001},
+		{"north galactic pole", Equatorial{192.85948 * rad, 27.12825 * rad}, 0, 90, 360},
+		{"north celestial pole", Equatorial{0, 90 * rad}, 122.93192, 27.12825, 0.001},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := EquatorialToGalactic(tt.eq)
+			l := math.Remainder(g.Longitude/rad-tt.l, 360)
+			if math.Abs(l) > tt.lTolerance || math.Abs(g.Latitude/rad-tt.b) > 0.001 {
+				t.Errorf("EquatorialToGalactic() = %v, %v, want %v, %v", g.Longitude/rad, g.Latitude/rad, tt.l, tt.b)
+			}
+		})
+	}
+}
+
+func TestRoundTrips(t *testing.T) {
+	date := time.Date(2021, 6, 21, 22, 30, 0, 0, time.UTC)
+	lat, lng := 48.85, 2.35
+	obliquity := suncalc.GetObliquity(date)
+
+	for ra := 5.0; ra < 360; ra += 25 {
+		for dec := -85.0; dec < 90; dec += 17 {
+			c := Equatorial{ra * rad, dec * rad}
+			for _, tt := range []struct {
+				name string
+				got  Equatorial
+			}{
+				{"ecliptic", EclipticToEquatorial(EquatorialToEcliptic(c, obliquity), obliquity)},
+				{"horizontal", HorizontalToEquatorial(EquatorialToHorizontal(c, date, lat, lng), date, lat, lng)},
+				{"galactic", GalacticToEquatorial(EquatorialToGalactic(c))},
+			} {
+				if math.Abs(math.Remainder(tt.got.RightAscension-c.RightAscension, 2*math.Pi)) > 1e-9 || math.Abs(tt.got.Declination-c.Declination) > 1e-9 {
+					t.Errorf("%s round trip of %v, %v = %v, %v", tt.name, ra, dec, tt.got.RightAscension/rad, tt.got.Declination/rad)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package suncalc
+
+import (
+	"time"
+)
+
+// precision of the instants returned by the crossing search
+const crossingPrecision = time.Second
+
+type crossing struct {
+	time   time.Time
+	rising bool
+}
+
+// samples f from start to end every step, and refines by bisection every
+// instant where f changes its sign (rising when f goes from negative to positive)
+func findCrossings(start time.Time, end time.Time, step time.Duration, f func(time.Time) float64) []crossing {
+	var result []crossing
+
+	t0 := start
+	v0 := f(t0)
+	for t0.Before(end) {
+		t1 := t0.Add(step)
+		if t1.After(end) {
+			t1 = end
+		}
+		v1 := f(t1)
+
+		if (v0 < 0) != (v1 < 0) {
+			result = append(result, crossing{bisect(t0, t1, v0, f), v0 < 0})
+		}
+
+		t0, v0 = t1, v1
+	}
+
+	return result
+}
+
+// returns the instant between t0 and t1 where f changes its sign, knowing f(t0) = v0
+func bisect(t0 time.Time, t1 time.Time, v0 float64, f func(time.Time) float64) time.Time {
+	return bisectCondition(t0, t1, v0 < 0, func(t time.Time) bool { return f(t) < 0 })
+}
+
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// samples the condition from start to end every step, and returns the intervals during
+// which it holds, their bounds being refined by bisection
+func findIntervals(start time.Time, end time.Time, step time.Duration, condition func(time.Time) bool) []Interval {
+	var result []Interval
+
+	t0 := start
+	c0 := condition(t0)
+	if c0 {
+		result = append(result, Interval{Start: start})
+	}
+	for t0.Before(end) {
+		t1 := t0.Add(step)
+		if t1.After(end) {
+			t1 = end
+		}
+		c1 := condition(t1)
+
+		if c0 != c1 {
+			edge := bisectCondition(t0, t1, c0, condition)
+			if c1 {
+				result = append(result, Interval{Start: edge})
+			} else {
+				result[len(result)-1].End = edge
+			}
+		}
+
+		t0, c0 = t1, c1
+	}
+	if c0 {
+		result[len(result)-1].End = end
+	}
+
+	return result
+}
+
+// returns the instant between t0 and t1 where the condition changes, knowing condition(t0) = c0
+func bisectCondition(t0 time.Time, t1 time.Time, c0 bool, condition func(time.Time) bool) time.Time {
+	for t1.Sub(t0) > crossingPrecision {
+		mid := t0.Add(t1.Sub(t0) / 2)
+		if condition(mid) == c0 {
+			t0 = mid
+		} else {
+			t1 = mid
+		}
+	}
+	return t0.Add(t1.Sub(t0) / 2)
+}
+
+// samples f from start to end every step, and returns the instant where it is the lowest,
+// refined by a golden section search around the lowest sample
+func findMinimum(start time.Time, end time.Time, step time.Duration, f func(time.Time) float64) time.Time {
+	best, bestValue := start, f(start)
+	for t := start.Add(step); !t.After(end); t = t.Add(step) {
+		if v := f(t); v < bestValue {
+			best, bestValue = t, v
+		}
+	}
+
+	lo, hi := best.Add(-step), best.Add(step)
+	if lo.Before(start) {
+		lo = start
+	}
+	if hi.After(end) {
+		hi = end
+	}
+
+	const ratio = 0.381966 // 2 - golden ratio
+	a := lo.Add(time.Duration(ratio * float64(hi.Sub(lo))))
+	b := hi.Add(-time.Duration(ratio * float64(hi.Sub(lo))))
+	fa, fb := f(a), f(b)
+	for hi.Sub(lo) > crossingPrecision {
+		if fa < fb {
+			hi, b, fb = b, a, fa
+			a = lo.Add(time.Duration(ratio * float64(hi.Sub(lo))))
+			fa = f(a)
+		} else {
+			lo, a, fa = a, b, fb
+			b = hi.Add(-time.Duration(ratio * float64(hi.Sub(lo))))
+			fb = f(b)
+		}
+	}
+	return lo.Add(hi.Sub(lo) / 2)
+}
@@ -0,0 +1,71 @@
+package suncalc
+
+import (
+	"time"
+)
+
+// DarkNight is an astronomical night, with the parts of it during which the moon does not light the sky
+type DarkNight struct {
+	// From the Night time of an evening to the NightEnd time of the next morning
+	Interval
+
+	// Parts of the night during which the moon is under the observer's horizon or dim enough
+	Dark []Interval
+}
+
+// returns the astronomical night starting on the local day of the date, from the Night time of GetTimesWithObserver
+// to the NightEnd time of the next day, or from a solar noon to the next one when the sun stays below -18°
+func astronomicalNightOf(day time.Time, obs Observer) (Interval, bool) {
+	noon := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, obs.Location)
+	evening := GetTimesWithObserver(noon, obs)
+	morning := GetTimesWithObserver(noon.AddDate(0, 0, 1), obs)
+
+	dark := func(t time.Time) bool {
+		return GetPosition(t, obs.Latitude, obs.Longitude).Altitude < astronomicalNight
+	}
+	if !dark(morning[Nadir].Value) {
+		return Interval{}, false
+	}
+
+	night := Interval{evening[Night].Value, morning[NightEnd].Value}
+	if night.Start.IsZero() {
+		night.Start = evening[SolarNoon].Value
+	}
+	if night.End.IsZero() {
+		night.End = morning[SolarNoon].Value
+	}
+	return night, true
+}
+
+// calculates the astronomical nights from start to end, cut to these bounds, and the parts of them during which
+// the upper edge of the moon is under the observer's horizon profile if any, or its illuminated fraction is at most
+// maxIllumination (0 to require the moon to be down)
+func GetDarkSkyWindows(start time.Time, end time.Time, obs Observer, maxIllumination float64) []DarkNight {
+	moonless := func(t time.Time) bool {
+		az, _, h := topocentric(t, obs.Latitude, obs.Longitude, Moon)
+		if h+Moon.Radius(t)+horizonRefraction < obs.horizonAltitude(az) { // as for the moonrise and the moonset of GetBodyTimes
+			return true
+		}
+		return maxIllumination > 0 && GetMoonIllumination(t).Fraction <= maxIllumination
+	}
+
+	var result []DarkNight
+	local := start.In(obs.Location)
+	for day := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, obs.Location); day.Before(end); day = day.AddDate(0, 0, 1) {
+		night, ok := astronomicalNightOf(day, obs)
+		if !ok {
+			continue
+		}
+		if night.Start.Before(start) {
+			night.Start = start
+		}
+		if night.End.After(end) {
+			night.End = end
+		}
+		if !night.Start.Before(night.End) {
+			continue
+		}
+		result = append(result, DarkNight{night, findIntervals(night.Start, night.End, horizonStep, moonless)})
+	}
+	return result
+}
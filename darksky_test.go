@@ -0,0 +1,79 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetDarkSkyWindows(t *testing.T) {
+	obs := Observer{Latitude: 48.85, Longitude: 2.35, Location: time.UTC}
+
+	tests := []struct {
+		name            string
+		start           time.Time
+		maxIllumination float64
+		dark            []bool // whether each night is entirely dark, or entirely moonlit
+	}{
+		{"new moon", time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC), 0, []bool{true, true}},
+		{"full moon", time.Date(2024, 1, 24, 12, 0, 0, 0, time.UTC), 0, []bool{false, false}},
+		{"full moon, any illumination", time.Date(2024, 1, 24, 12, 0, 0, 0, time.UTC), 1, []bool{true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetDarkSkyWindows(tt.start, tt.start.AddDate(0, 0, 2), obs, tt.maxIllumination)
+			if len(got) != len(tt.dark) {
+				t.Fatalf("GetDarkSkyWindows() = %v, want %v nights", got, len(tt.dark))
+			}
+			for i, n := range got {
+				if tt.dark[i] && (len(n.Dark) != 1 || n.Dark[0] != n.Interval) {
+					t.Errorf("GetDarkSkyWindows() night %v dark %v, want the whole night", n.Interval, n.Dark)
+				}
+				if !tt.dark[i] && len(n.Dark) != 0 {
+					t.Errorf("GetDarkSkyWindows() night %v dark %v, want none", n.Interval, n.Dark)
+				}
+			}
+		})
+	}
+}
+
+func TestGetDarkSkyWindowsMoonset(t *testing.T) {
+	// the first quarter moon sets after midnight, and the sky is dark until the morning twilight
+	obs := Observer{Latitude: 48.85, Longitude: 2.35, Location: time.UTC}
+	start := time.Date(2024, 1, 18, 12, 0, 0, 0, time.UTC)
+	got := GetDarkSkyWindows(start, start.AddDate(0, 0, 1), obs, 0)
+	if len(got) != 1 || len(got[0].Dark) != 1 {
+		t.Fatalf("GetDarkSkyWindows() = %v, want a single dark interval", got)
+	}
+
+	times := GetTimesWithObserver(start, obs)
+	nextTimes := GetTimesWithObserver(start.AddDate(0, 0, 1), obs)
+	if !got[0].Start.Equal(times[Night].Value) || !got[0].End.Equal(nextTimes[NightEnd].Value) {
+		t.Errorf("GetDarkSkyWindows() night = %v, want from %v to %v", got[0].Interval, times[Night].Value, nextTimes[NightEnd].Value)
+	}
+
+	moonset := GetBodyTimes(start.AddDate(0, 0, 1), obs, Moon).Set
+	if dark := got[0].Dark[0]; math.Abs(dark.Start.Sub(moonset).Seconds()) > 5 || !dark.End.Equal(got[0].End) {
+		t.Errorf("GetDarkSkyWindows() dark = %v, want from the moonset %v to the end of the night", dark, moonset)
+	}
+}
+
+func TestGetDarkSkyWindowsPolar(t *testing.T) {
+	start := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	if got := GetDarkSkyWindows(start, start.AddDate(0, 0, 2), Observer{Latitude: 55, Location: time.UTC}, 0); len(got) != 0 {
+		t.Errorf("GetDarkSkyWindows() in June at 55°N = %v, want no astronomical night", got)
+	}
+
+	// the polar night goes on from a solar noon to the next one
+	start = time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 3)
+	got := GetDarkSkyWindows(start, end, Observer{Latitude: 89.9, Location: time.UTC}, 1)
+	if len(got) != 4 || !got[0].Start.Equal(start) || !got[3].End.Equal(end) {
+		t.Fatalf("GetDarkSkyWindows() at the pole = %v, want 4 nights from %v to %v", got, start, end)
+	}
+	for i := 1; i < len(got); i++ {
+		if !got[i].Start.Equal(got[i-1].End) {
+			t.Errorf("GetDarkSkyWindows() night %v does not follow %v", got[i].Interval, got[i-1].Interval)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package suncalc
+
+import (
+	"math"
+	"time"
+)
+
+type EclipseKind int
+
+const (
+	NoEclipse EclipseKind = iota
+	PartialEclipse
+	AnnularEclipse
+	TotalEclipse
+	HybridEclipse
+	PenumbralEclipse
+)
+
+func (k EclipseKind) String() string {
+	switch k {
+	case PartialEclipse:
+		return "partial"
+	case AnnularEclipse:
+		return "annular"
+	case TotalEclipse:
+		return "total"
+	case HybridEclipse:
+		return "hybrid"
+	case PenumbralEclipse:
+		return "penumbral"
+	}
+	return "none"
+}
+
+const (
+	sunRadiusKm = 696000
+
+	// radius of the moon relative to the Earth, for the penumbra and the umbra (values of the NASA canon)
+	moonPenumbralRadius = 0.2725076
+	moonUmbralRadius    = 0.272281
+)
+
+// besselian holds the Besselian elements of a solar eclipse at an instant: the position of the shadow axis
+// and the radii of the shadow cones on the fundamental plane, through the center of the Earth and
+// perpendicular to the axis, in Earth radii
+type besselian struct {
+	x, y         float64
+	d, mu        float64 // declination and Greenwich hour angle of the axis
+	l1, l2       float64 // radii of the penumbra and of the umbra, the latter negative when the eclipse is total
+	tanF1, tanF2 float64 // slopes of the shadow cones
+}
+
+// calculates the Besselian elements at a given date
+func besselianElements(date time.Time) besselian {
+	sun, moon := accurateSunMoon(date)
+
+	rectangular := func(c EquatorialCoordinates) [3]float64 {
+		r := c.Distance / earthRadiusKm
+		return [3]float64{
+			r * math.Cos(c.Declination) * math.Cos(c.RightAscension),
+			r * math.Cos(c.Declination) * math.Sin(c.RightAscension),
+			r * math.Sin(c.Declination),
+		}
+	}
+	s, m := rectangular(sun), rectangular(moon)
+	g := [3]float64{s[0] - m[0], s[1] - m[1], s[2] - m[2]}
+	distance := math.Sqrt(g[0]*g[0] + g[1]*g[1] + g[2]*g[2])
+
+	a := math.Atan2(g[1], g[0])
+	d := math.Asin(g[2] / distance)
+
+	rm := moon.Distance / earthRadiusKm
+	sinD, cosD := math.Sincos(d)
+	sinDm, cosDm := math.Sincos(moon.Declination)
+	H := moon.RightAscension - a
+	z := rm * (sinDm*sinD + cosDm*cosD*math.Cos(H))
+
+	f1 := math.Asin((sunRadiusKm/earthRadiusKm + moonPenumbralRadius) / distance)
+	f2 := math.Asin((sunRadiusKm/earthRadiusKm - moonUmbralRadius) / distance)
+
+	return besselian{
+		x:     rm * cosDm * math.Sin(H),
+		y:     rm * (sinDm*cosD - cosDm*sinD*math.Cos(H)),
+		d:     d,
+		mu:    siderealTime(toDays(date), 0) - a,
+		l1:    z*math.Tan(f1) + moonPenumbralRadius/math.Cos(f1),
+		l2:    z*math.Tan(f2) - moonUmbralRadius/math.Cos(f2),
+		tanF1: math.Tan(f1),
+		tanF2: math.Tan(f2),
+	}
+}
+
+// geocentric coordinates of an observer, in Earth radii
+type geocentric struct {
+	rhoSinPhi, rhoCosPhi float64
+	lng                  float64 // radians, east positive
+}
+
+func newGeocentric(obs Observer) geocentric {
+	const flattening = 0.99664719 // ratio of the polar and equatorial radii
+	phi := obs.Latitude * rad
+	u := math.Atan(flattening * math.Tan(phi))
+	h := obs.Height / (earthRadiusKm * 1000)
+	return geocentric{
+		flattening*math.Sin(u) + h*math.Sin(phi),
+		math.Cos(u) + h*math.Cos(phi),
+		obs.Longitude * rad,
+	}
+}
+
+// returns the distance of the observer to the shadow axis, and the radii of the penumbra and the umbra at the observer
+func (b besselian) at(g geocentric) (m float64, L1 float64, L2 float64) {
+	sinD, cosD := math.Sincos(b.d)
+	sinH, cosH := math.Sincos(b.mu + g.lng)
+	xi := g.rhoCosPhi * sinH
+	eta := g.rhoSinPhi*cosD - g.rhoCosPhi*cosH*sinD
+	zeta := g.rhoSinPhi*sinD + g.rhoCosPhi*cosH*cosD
+	return math.Hypot(b.x-xi, b.y-eta), b.l1 - zeta*b.tanF1, b.l2 - zeta*b.tanF2
+}
+
+type SolarEclipse struct {
+	Kind EclipseKind
+
+	// Instant the shadow axis passes the closest to the center of the Earth
+	Greatest time.Time
+
+	// Distance of the shadow axis to the center of the Earth at the greatest eclipse, in Earth radii,
+	// positive when it passes north of it
+	Gamma float64
+
+	// Fraction of the diameter of the sun covered by the moon at the greatest eclipse,
+	// or ratio of their diameters when central
+	Magnitude float64
+}
+
+// how far from the new moon the greatest eclipse is looked for
+const eclipseWindow = 6 * time.Hour
+
+// lists the solar eclipses between start and end
+func GetSolarEclipses(start time.Time, end time.Time) []SolarEclipse {
+	var result []SolarEclipse
+	for _, newMoon := range findSyzygies(start, end, false) {
+		greatest := findMinimum(newMoon.Add(-eclipseWindow), newMoon.Add(eclipseWindow), 10*time.Minute, func(t time.Time) float64 {
+			b := besselianElements(t)
+			return math.Hypot(b.x, b.y)
+		})
+
+		b := besselianElements(greatest)
+		gamma := math.Copysign(math.Hypot(b.x, b.y), b.y)
+		if math.Abs(gamma) > 1+b.l1 {
+			continue
+		}
+
+		// the greatest eclipse is on the Earth under the axis, or on its limb closest to the axis
+		eclipse := SolarEclipse{Kind: PartialEclipse, Greatest: greatest, Gamma: gamma}
+		zeta, m := 0.0, math.Abs(gamma)-1
+		if math.Abs(gamma) < 1 {
+			zeta, m = math.Sqrt(1-gamma*gamma), 0
+		}
+		L1, L2 := b.l1-zeta*b.tanF1, b.l2-zeta*b.tanF2
+		eclipse.Magnitude = magnitude(m, L1, L2)
+
+		if math.Abs(gamma) < 1+math.Abs(b.l2) {
+			switch {
+			case L2 < 0 && b.l2 > 0: // total in the middle of the path, annular at its ends
+				eclipse.Kind = HybridEclipse
+			case L2 < 0:
+				eclipse.Kind = TotalEclipse
+			default:
+				eclipse.Kind = AnnularEclipse
+			}
+		}
+		result = append(result, eclipse)
+	}
+	return result
+}
+
+// returns the new moons (or the full moons) between start and end, when the difference
+// of the ecliptic longitudes of the moon and the sun is zero (or 180°)
+func findSyzygies(start time.Time, end time.Time, full bool) []time.Time {
+	var result []time.Time
+	for _, c := range findCrossings(start, end, 24*time.Hour, func(t time.Time) float64 {
+		T := julianCenturiesTT(t)
+		sun, _ := accurateSunEcliptic(T)
+		moon, _, _ := accurateMoonEcliptic(T)
+		return math.Sin(moon - sun)
+	}) {
+		// the sine rises through zero at the new moon, and falls at the full moon
+		if c.rising != full {
+			result = append(result, c.time)
+		}
+	}
+	return result
+}
+
+type EclipseContact struct {
+	Time time.Time
+
+	// Altitude of the sun, or of the moon for a lunar eclipse, in radians
+	Altitude float64
+}
+
+// LocalSolarEclipse holds the circumstances of a solar eclipse seen from an observer
+type LocalSolarEclipse struct {
+	// NoEclipse when the moon does not cover the sun from the observer while the sun is above the horizon
+	Kind EclipseKind
+
+	// Beginning and end of the partial phase (C1, C4), and of the total or annular one (C2, C3),
+	// which are zero when the observer is not in the path of the umbra. They may happen
+	// while the sun is below the horizon, see their altitude
+	C1, C2, C3, C4 EclipseContact
+	Maximum        EclipseContact
+
+	// Fraction of the diameter of the sun covered by the moon at the maximum,
+	// or ratio of their diameters during the total or annular phase
+	Magnitude float64
+
+	// Fraction of the area of the sun covered by the moon at the maximum
+	Obscuration float64
+}
+
+// calculates the circumstances of the solar eclipse seen from the observer
+func GetLocalSolarEclipse(eclipse SolarEclipse, obs Observer) LocalSolarEclipse {
+	g := newGeocentric(obs)
+	start, end := eclipse.Greatest.Add(-eclipseWindow), eclipse.Greatest.Add(eclipseWindow)
+	contact := func(t time.Time) EclipseContact {
+		return EclipseContact{t, GetPosition(t, obs.Latitude, obs.Longitude).Altitude}
+	}
+
+	var result LocalSolarEclipse
+	for _, c := range findCrossings(start, end, 5*time.Minute, func(t time.Time) float64 {
+		m, L1, _ := besselianElements(t).at(g)
+		return m - L1
+	}) {
+		if c.rising {
+			result.C4 = contact(c.time)
+		} else if result.C1.Time.IsZero() {
+			result.C1 = contact(c.time)
+		}
+	}
+	if result.C1.Time.IsZero() || result.C4.Time.IsZero() {
+		return LocalSolarEclipse{}
+	}
+
+	// the shadow cones go on through the Earth, the eclipse is only seen when the sun is up
+	if len(findIntervals(result.C1.Time, result.C4.Time, time.Minute, func(t time.Time) bool {
+		return GetPosition(t, obs.Latitude, obs.Longitude).Altitude > -0.833*rad
+	})) == 0 {
+		return LocalSolarEclipse{}
+	}
+
+	result.Kind = PartialEclipse
+	maximum := findMinimum(result.C1.Time, result.C4.Time, time.Minute, func(t time.Time) float64 {
+		m, _, _ := besselianElements(t).at(g)
+		return m
+	})
+	result.Maximum = contact(maximum)
+	m, L1, L2 := besselianElements(maximum).at(g)
+	result.Magnitude = magnitude(m, L1, L2)
+	result.Obscuration = obscuration(m, (L1+L2)/2, (L1-L2)/2)
+
+	if m < math.Abs(L2) {
+		result.Kind = AnnularEclipse
+		if L2 < 0 {
+			result.Kind = TotalEclipse
+		}
+		for _, c := range findCrossings(result.C1.Time, result.C4.Time, time.Minute, func(t time.Time) float64 {
+			m, _, L2 := besselianElements(t).at(g)
+			return m - math.Abs(L2)
+		}) {
+			if c.rising {
+				result.C3 = contact(c.time)
+			} else {
+				result.C2 = contact(c.time)
+			}
+		}
+	}
+	return result
+}
+
+// returns the magnitude of a solar eclipse, at distance m from the shadow axis: the fraction of the diameter
+// of the sun covered by the moon, or the ratio of their diameters during the total or annular phase
+func magnitude(m float64, L1 float64, L2 float64) float64 {
+	if m < math.Abs(L2) {
+		return (L1 - L2) / (L1 + L2)
+	}
+	return (L1 - m) / (L1 + L2)
+}
+
+// returns the fraction of the area of a disk of radius r1 covered by a disk of radius r2, their centers being at distance m
+func obscuration(m float64, r1 float64, r2 float64) float64 {
+	switch {
+	case m >= r1+r2:
+		return 0
+	case m <= math.Abs(r2-r1):
+		return math.Min(1, r2*r2/(r1*r1))
+	}
+	area := r1*r1*math.Acos((m*m+r1*r1-r2*r2)/(2*m*r1)) + r2*r2*math.Acos((m*m+r2*r2-r1*r1)/(2*m*r2)) -
+		0.5*math.Sqrt((-m+r1+r2)*(m+r1-r2)*(m-r1+r2)*(m+r1+r2))
+	return area / (math.Pi * r1 * r1)
+}
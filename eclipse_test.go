@@ -0,0 +1,90 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetSolarEclipses(t *testing.T) {
+	// NASA five millennium canon of solar eclipses
+	want := []SolarEclipse{
+		{PartialEclipse, time.Date(2022, 4, 30, 20, 41, 6, 0, time.UTC), -1.1901, 0.6396},
+		{PartialEclipse, time.Date(2022, 10, 25, 10, 59, 52, 0, time.UTC), 1.0701, 0.8619},
+		{HybridEclipse, time.Date(2023, 4, 20, 4, 16, 49, 0, time.UTC), -0.3952, 1.0132},
+		{AnnularEclipse, time.Date(2023, 10, 14, 17, 59, 29, 0, time.UTC), 0.3753, 0.9520},
+		{TotalEclipse, time.Date(2024, 4, 8, 18, 17, 16, 0, time.UTC), 0.3431, 1.0566},
+		{AnnularEclipse, time.Date(2024, 10, 2, 18, 45, 13, 0, time.UTC), -0.3509, 0.9326},
+		{PartialEclipse, time.Date(2025, 3, 29, 10, 47, 27, 0, time.UTC), 1.0405, 0.9376},
+		{PartialEclipse, time.Date(2025, 9, 21, 19, 42, 4, 0, time.UTC), -1.0651, 0.8550},
+	}
+
+	got := GetSolarEclipses(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(got) != len(want) {
+		t.Fatalf("GetSolarEclipses() = %d eclipses, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		w := want[i]
+		if d := e.Greatest.Sub(w.Greatest); e.Kind != w.Kind || d > time.Minute || d < -time.Minute ||
+			math.Abs(e.Gamma-w.Gamma) > 0.002 || math.Abs(e.Magnitude-w.Magnitude) > 0.01 {
+			t.Errorf("GetSolarEclipses()[%d] = %v %v γ=%v mag=%v, want %v %v γ=%v mag=%v", i,
+				e.Kind, e.Greatest, e.Gamma, e.Magnitude, w.Kind, w.Greatest, w.Gamma, w.Magnitude)
+		}
+	}
+}
+
+func TestGetLocalSolarEclipse(t *testing.T) {
+	eclipses := GetSolarEclipses(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	if len(eclipses) != 1 {
+		t.Fatalf("GetSolarEclipses() = %v, want the eclipse of 2024-04-08", eclipses)
+	}
+
+	near := func(a EclipseContact, b time.Time) bool {
+		d := a.Time.Sub(b)
+		return d < time.Minute && d > -time.Minute
+	}
+
+	// Dallas, Texas
+	got := GetLocalSolarEclipse(eclipses[0], Observer{32.7767, -96.7970, 140, time.UTC, nil})
+	if got.Kind != TotalEclipse ||
+		!near(got.C1, time.Date(2024, 4, 8, 17, 23, 14, 0, time.UTC)) ||
+		!near(got.C2, time.Date(2024, 4, 8, 18, 40, 43, 0, time.UTC)) ||
+		!near(got.C3, time.Date(2024, 4, 8, 18, 44, 35, 0, time.UTC)) ||
+		!near(got.C4, time.Date(2024, 4, 8, 20, 2, 48, 0, time.UTC)) {
+		t.Errorf("GetLocalSolarEclipse() Dallas = %+v", got)
+	}
+	if got.Obscuration != 1 || got.Magnitude < 1 || got.Maximum.Time.Before(got.C2.Time) || got.Maximum.Time.After(got.C3.Time) {
+		t.Errorf("GetLocalSolarEclipse() Dallas maximum = %+v, magnitude %v, obscuration %v", got.Maximum, got.Magnitude, got.Obscuration)
+	}
+	if math.Abs(got.C2.Altitude-GetPosition(got.C2.Time, 32.7767, -96.7970).Altitude) > 1e-12 || got.C2.Altitude < 60*rad {
+		t.Errorf("GetLocalSolarEclipse() Dallas sun altitude = %v", got.C2.Altitude/rad)
+	}
+
+	// partial at sunset in Ireland, below the horizon in Paris and Tokyo
+	if got := GetLocalSolarEclipse(eclipses[0], Observer{53.35, -6.26, 0, time.UTC, nil}); got.Kind != PartialEclipse || got.C4.Altitude > 0 || !got.C2.Time.IsZero() {
+		t.Errorf("GetLocalSolarEclipse() Dublin = %+v", got)
+	}
+	for _, obs := range []Observer{{48.85, 2.35, 0, time.UTC, nil}, {35.68, 139.69, 0, time.UTC, nil}} {
+		if got := GetLocalSolarEclipse(eclipses[0], obs); got.Kind != NoEclipse {
+			t.Errorf("GetLocalSolarEclipse() at %v, %v = %+v", obs.Latitude, obs.Longitude, got)
+		}
+	}
+}
+
+func TestObscuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		m, r1, r2 float64
+		want      float64
+	}{
+		{"apart", 3, 1, 1, 0},
+		{"total", 0.01, 1, 1.05, 1},
+		{"annular", 0, 1, 0.9, 0.81},
+		{"half way", 1, 1, 1, (2*math.Pi/3 - math.Sqrt(3)/2) / math.Pi},
+	}
+	for _, tt := range tests {
+		if got := obscuration(tt.m, tt.r1, tt.r2); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("obscuration() %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
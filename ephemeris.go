@@ -0,0 +1,286 @@
+package suncalc
+
+import (
+	"math"
+	"time"
+
+	"github.com/sixdouglas/suncalc/internal/sphere"
+)
+
+// more accurate sun and moon models than the ones of GetPosition and GetMoonPosition, for the
+// calculations needing positions to a few seconds of arc, like eclipses. Based on "Astronomical
+// Algorithms" 2nd edition by Jean Meeus (Willmann-Bell, Richmond) 1998.
+
+const earthRadiusKm = 6378.137
+
+// returns the difference between the terrestrial and the universal time in seconds, polynomial
+// expressions by Espenak and Meeus (NASA eclipse web site)
+func deltaT(date time.Time) float64 {
+	y := float64(date.Year()) + (float64(date.YearDay())-0.5)/365.25
+	switch {
+	case y >= 2050 && y < 2150:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	case y >= 2005 && y < 2050:
+		t := y - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	case y >= 1986 && y < 2005:
+		t := y - 2000
+		return 63.86 + t*(0.3345+t*(-0.060374+t*(0.0017275+t*(0.000651814+t*0.00002373599))))
+	case y >= 1961 && y < 1986:
+		t := y - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case y >= 1941 && y < 1961:
+		t := y - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case y >= 1920 && y < 1941:
+		t := y - 1920
+		return 21.20 + t*(0.84493+t*(-0.076100+t*0.0020936))
+	case y >= 1900 && y < 1920:
+		t := y - 1900
+		return -2.79 + t*(1.494119+t*(-0.0598939+t*(0.0061966-t*0.000197)))
+	}
+	u := (y - 1820) / 100
+	return -20 + 32*u*u
+}
+
+// returns the Julian centuries of terrestrial time since J2000 for a date in universal time
+func julianCenturiesTT(date time.Time) float64 {
+	return (toJulian(date) + deltaT(date)/86400 - J2000) / 36525
+}
+
+// returns the nutation in longitude and in obliquity, in radians (low precision, chapter 22)
+func nutation(T float64) (float64, float64) {
+	omega := rad * (125.04452 - 1934.136261*T)
+	L := rad * (280.4665 + 36000.7698*T)
+	Lm := rad * (218.3165 + 481267.8813*T)
+
+	arcsec := rad / 3600
+	dpsi := arcsec * (-17.20*math.Sin(omega) - 1.32*math.Sin(2*L) - 0.23*math.Sin(2*Lm) + 0.21*math.Sin(2*omega))
+	deps := arcsec * (9.20*math.Cos(omega) + 0.57*math.Cos(2*L) + 0.10*math.Cos(2*Lm) - 0.09*math.Cos(2*omega))
+	return dpsi, deps
+}
+
+// returns the mean obliquity of the ecliptic, in radians (formula 22.2)
+func meanObliquity(T float64) float64 {
+	return rad * (23.439291111 + T*(-46.8150+T*(-0.00059+T*0.001813))/3600)
+}
+
+// precesses ecliptic coordinates referred to the equinox J2000 to the mean equinox of the date,
+// T being in Julian centuries since J2000 (formulas 21.5 to 21.7)
+func precessEcliptic(lon float64, lat float64, T float64) (float64, float64) {
+	arcsec := rad / 3600
+	eta := arcsec * T * (47.0029 + T*(-0.03302+T*0.000060))
+	pi := rad*174.876384 + arcsec*T*(-869.8089+T*0.03536)
+	p := arcsec * T * (5029.0966 + T*(1.11113-T*0.000006))
+
+	sinEta, cosEta := math.Sincos(eta)
+	sinB, cosB := math.Sincos(lat)
+	sinL, cosL := math.Sincos(pi - lon)
+	A := cosEta*cosB*sinL - sinEta*sinB
+	B := cosB * cosL
+	C := cosEta*sinB + sinEta*cosB*sinL
+	return p + pi - math.Atan2(A, B), math.Asin(C)
+}
+
+// returns the apparent geocentric ecliptic longitude of the sun in radians, and its distance in AU (chapter 25)
+func accurateSunEcliptic(T float64) (float64, float64) {
+	L0 := 280.46646 + T*(36000.76983+T*0.0003032)
+	M := rad * (357.52911 + T*(35999.05029-T*0.0001537))
+	e := 0.016708634 - T*(0.000042037+T*0.0000001267)
+	C := (1.914602-T*(0.004817+T*0.000014))*math.Sin(M) + (0.019993-T*0.000101)*math.Sin(2*M) + 0.000289*math.Sin(3*M)
+
+	nu := M + rad*C
+	R := 1.000001018 * (1 - e*e) / (1 + e*math.Cos(nu))
+
+	dpsi, _ := nutation(T)
+	aberration := -rad * 20.4898 / 3600 / R
+	return rad*(L0+C) + dpsi + aberration, R
+}
+
+// periodic terms of the longitude (1e-6 degrees) and distance (1e-3 km) of the moon: D, M, M', F, l, r (table 47.A)
+var moonLongitudeTerms = [][6]float64{
+	{0, 0, 1, 0, 6288774, -20905355},
+	{2, 0, -1, 0, 1274027, -3699111},
+	{2, 0, 0, 0, 658314, -2955968},
+	{0, 0, 2, 0, 213618, -569925},
+	{0, 1, 0, 0, -185116, 48888},
+	{0, 0, 0, 2, -114332, -3149},
+	{2, 0, -2, 0, 58793, 246158},
+	{2, -1, -1, 0, 57066, -152138},
+	{2, 0, 1, 0, 53322, -170733},
+	{2, -1, 0, 0, 45758, -204586},
+	{0, 1, -1, 0, -40923, -129620},
+	{1, 0, 0, 0, -34720, 108743},
+	{0, 1, 1, 0, -30383, 104755},
+	{2, 0, 0, -2, 15327, 10321},
+	{0, 0, 1, 2, -12528, 0},
+	{0, 0, 1, -2, 10980, 79661},
+	{4, 0, -1, 0, 10675, -34782},
+	{0, 0, 3, 0, 10034, -23210},
+	{4, 0, -2, 0, 8548, -21636},
+	{2, 1, -1, 0, -7888, 24208},
+	{2, 1, 0, 0, -6766, 30824},
+	{1, 0, -1, 0, -5163, -8379},
+	{1, 1, 0, 0, 4987, -16675},
+	{2, -1, 1, 0, 4036, -12831},
+	{2, 0, 2, 0, 3994, -10445},
+	{4, 0, 0, 0, 3861, -11650},
+	{2, 0, -3, 0, 3665, 14403},
+	{0, 1, -2, 0, -2689, -7003},
+	{2, 0, -1, 2, -2602, 0},
+	{2, -1, -2, 0, 2390, 10056},
+	{1, 0, 1, 0, -2348, 6322},
+	{2, -2, 0, 0, 2236, -9884},
+	{0, 1, 2, 0, -2120, 5751},
+	{0, 2, 0, 0, -2069, 0},
+	{2, -2, -1, 0, 2048, -4950},
+	{2, 0, 1, -2, -1773, 4130},
+	{2, 0, 0, 2, -1595, 0},
+	{4, -1, -1, 0, 1215, -3958},
+	{0, 0, 2, 2, -1110, 0},
+	{3, 0, -1, 0, -892, 3258},
+	{2, 1, 1, 0, -810, 2616},
+	{4, -1, -2, 0, 759, -1897},
+	{0, 2, -1, 0, -713, -2117},
+	{2, 2, -1, 0, -700, 2354},
+	{2, 1, -2, 0, 691, 0},
+	{2, -1, 0, -2, 596, 0},
+	{4, 0, 1, 0, 549, -1423},
+	{0, 0, 4, 0, 537, -1117},
+	{4, -1, 0, 0, 520, -1571},
+	{1, 0, -2, 0, -487, -1739},
+	{2, 1, 0, -2, -399, 0},
+	{0, 0, 2, -2, -381, -4421},
+	{1, 1, 1, 0, 351, 0},
+	{3, 0, -2, 0, -340, 0},
+	{4, 0, -3, 0, 330, 0},
+	{2, -1, 2, 0, 327, 0},
+	{0, 2, 1, 0, -323, 1165},
+	{1, 1, -1, 0, 299, 0},
+	{2, 0, 3, 0, 294, 0},
+	{2, 0, -1, -2, 0, 8752},
+}
+
+// periodic terms of the latitude of the moon (1e-6 degrees): D, M, M', F, b (table 47.B)
+var moonLatitudeTerms = [][5]float64{
+	{0, 0, 0, 1, 5128122},
+	{0, 0, 1, 1, 280602},
+	{0, 0, 1, -1, 277693},
+	{2, 0, 0, -1, 173237},
+	{2, 0, -1, 1, 55413},
+	{2, 0, -1, -1, 46271},
+	{2, 0, 0, 1, 32573},
+	{0, 0, 2, 1, 17198},
+	{2, 0, 1, -1, 9266},
+	{0, 0, 2, -1, 8822},
+	{2, -1, 0, -1, 8216},
+	{2, 0, -2, -1, 4324},
+	{2, 0, 1, 1, 4200},
+	{2, 1, 0, -1, -3359},
+	{2, -1, -1, 1, 2463},
+	{2, -1, 0, 1, 2211},
+	{2, -1, -1, -1, 2065},
+	{0, 1, -1, -1, -1870},
+	{4, 0, -1, -1, 1828},
+	{0, 1, 0, 1, -1794},
+	{0, 0, 0, 3, -1749},
+	{0, 1, -1, 1, -1565},
+	{1, 0, 0, 1, -1491},
+	{0, 1, 1, 1, -1475},
+	{0, 1, 1, -1, -1410},
+	{0, 1, 0, -1, -1344},
+	{1, 0, 0, -1, -1335},
+	{0, 0, 3, 1, 1107},
+	{4, 0, 0, -1, 1021},
+	{4, 0, -1, 1, 833},
+	{0, 0, 1, -3, 777},
+	{4, 0, -2, 1, 671},
+	{2, 0, 0, -3, 607},
+	{2, 0, 2, -1, 596},
+	{2, -1, 1, -1, 491},
+	{2, 0, -2, 1, -451},
+	{0, 0, 3, -1, 439},
+	{2, 0, 2, 1, 422},
+	{2, 0, -3, -1, 421},
+	{2, 1, -1, 1, -366},
+	{2, 1, 0, 1, -351},
+	{4, 0, 0, 1, 331},
+	{2, -1, 1, 1, 315},
+	{2, -2, 0, -1, 302},
+	{0, 0, 1, 3, -283},
+	{2, 1, 1, -1, -229},
+	{1, 1, 0, -1, 223},
+	{1, 1, 0, 1, 223},
+	{0, 1, -2, -1, -220},
+	{2, 1, -1, -1, -220},
+	{1, 0, 1, 1, -185},
+	{2, -1, -2, -1, 181},
+	{0, 1, 2, 1, -177},
+	{4, 0, -2, -1, 176},
+	{4, -1, -1, -1, 166},
+	{1, 0, 1, -1, -164},
+	{4, 0, 1, -1, 132},
+	{1, 0, -1, -1, -119},
+	{4, -1, 0, -1, 115},
+	{2, -2, 0, 1, 107},
+}
+
+// returns the apparent geocentric ecliptic longitude and latitude of the moon in radians,
+// and its distance in km (chapter 47)
+func accurateMoonEcliptic(T float64) (float64, float64, float64) {
+	Lm := 218.3164477 + T*(481267.88123421+T*(-0.0015786+T*(1.0/538841-T/65194000)))
+	D := 297.8501921 + T*(445267.1114034+T*(-0.0018819+T*(1.0/545868-T/113065000)))
+	M := 357.5291092 + T*(35999.0502909+T*(-0.0001536+T/24490000))
+	Mm := 134.9633964 + T*(477198.8675055+T*(0.0087414+T*(1.0/69699-T/14712000)))
+	F := 93.2720950 + T*(483202.0175233+T*(-0.0036539+T*(-1.0/3526000+T/863310000)))
+	A1 := rad * (119.75 + 131.849*T)
+	A2 := rad * (53.09 + 479264.290*T)
+	A3 := rad * (313.45 + 481266.484*T)
+	E := 1 - T*(0.002516+T*0.0000074)
+
+	// terms depending on the mean anomaly of the sun are multiplied by E, or E² when doubled
+	eccentricity := func(m float64) float64 {
+		switch math.Abs(m) {
+		case 1:
+			return E
+		case 2:
+			return E * E
+		}
+		return 1
+	}
+
+	var sl, sr, sb float64
+	for _, t := range moonLongitudeTerms {
+		arg := rad * (t[0]*D + t[1]*M + t[2]*Mm + t[3]*F)
+		sin, cos := math.Sincos(arg)
+		sl += t[4] * eccentricity(t[1]) * sin
+		sr += t[5] * eccentricity(t[1]) * cos
+	}
+	for _, t := range moonLatitudeTerms {
+		sb += t[4] * eccentricity(t[1]) * math.Sin(rad*(t[0]*D+t[1]*M+t[2]*Mm+t[3]*F))
+	}
+
+	sl += 3958*math.Sin(A1) + 1962*math.Sin(rad*(Lm-F)) + 318*math.Sin(A2)
+	sb += -2235*math.Sin(rad*Lm) + 382*math.Sin(A3) + 175*math.Sin(A1-rad*F) + 175*math.Sin(A1+rad*F) +
+		127*math.Sin(rad*(Lm-Mm)) - 115*math.Sin(rad*(Lm+Mm))
+
+	dpsi, _ := nutation(T)
+	return rad*(Lm+sl/1e6) + dpsi, rad * sb / 1e6, 385000.56 + sr/1000
+}
+
+// returns the apparent right ascension, declination and distance (in km) of the sun and the moon
+func accurateSunMoon(date time.Time) (sun EquatorialCoordinates, moon EquatorialCoordinates) {
+	T := julianCenturiesTT(date)
+	_, deps := nutation(T)
+	obliquity := meanObliquity(T) + deps
+
+	lon, R := accurateSunEcliptic(T)
+	sun.RightAscension, sun.Declination = sphere.EclipticToEquatorial(lon, 0, obliquity)
+	sun.Distance = R * astronomicalUnit
+
+	lon, lat, dist := accurateMoonEcliptic(T)
+	moon.RightAscension, moon.Declination = sphere.EclipticToEquatorial(lon, lat, obliquity)
+	moon.Distance = dist
+	return sun, moon
+}
@@ -0,0 +1,75 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAccurateMoonEcliptic(t *testing.T) {
+	// Meeus, example 47.a: 1992-04-12 0h TD
+	lon, lat, dist := accurateMoonEcliptic((2448724.5 - J2000) / 36525)
+	if got := math.Mod(lon/rad, 360) + 360; math.Abs(got-133.167265) > 0.0002 {
+		t.Errorf("accurateMoonEcliptic() longitude = %v, want 133.167265", got)
+	}
+	if got := lat / rad; math.Abs(got+3.229126) > 0.000001 {
+		t.Errorf("accurateMoonEcliptic() latitude = %v, want -3.229126", got)
+	}
+	if math.Abs(dist-368409.7) > 0.1 {
+		t.Errorf("accurateMoonEcliptic() distance = %v, want 368409.7", dist)
+	}
+}
+
+func TestAccurateSunEcliptic(t *testing.T) {
+	// Meeus, example 25.a: 1992-10-13 0h TD
+	lon, R := accurateSunEcliptic((2448908.5 - J2000) / 36525)
+	if got := math.Mod(lon/rad, 360) + 360; math.Abs(got-199.90895) > 0.001 {
+		t.Errorf("accurateSunEcliptic() longitude = %v, want 199.90895", got)
+	}
+	if math.Abs(R-0.99766) > 0.00001 {
+		t.Errorf("accurateSunEcliptic() distance = %v, want 0.99766", R)
+	}
+}
+
+func TestNutation(t *testing.T) {
+	// Meeus, example 22.a: 1987-04-10 0h TD
+	T := (2446895.5 - J2000) / 36525
+	dpsi, deps := nutation(T)
+	if got := dpsi / rad * 3600; math.Abs(got+3.788) > 0.5 {
+		t.Errorf("nutation() in longitude = %v\", want -3.788\"", got)
+	}
+	if got := deps / rad * 3600; math.Abs(got-9.443) > 0.1 {
+		t.Errorf("nutation() in obliquity = %v\", want 9.443\"", got)
+	}
+	if got := (meanObliquity(T) + deps) / rad; math.Abs(got-23.4435694) > 0.00003 {
+		t.Errorf("true obliquity = %v, want 23.4435694", got)
+	}
+}
+
+func TestDeltaT(t *testing.T) {
+	tests := []struct {
+		year int
+		want float64
+	}{
+		{1900, -2.79},
+		{1950, 29.07},
+		{2000, 63.86},
+		{2020, 71.6},
+	}
+	for _, tt := range tests {
+		if got := deltaT(time.Date(tt.year, 1, 1, 0, 0, 0, 0, time.UTC)); math.Abs(got-tt.want) > 0.5 {
+			t.Errorf("deltaT(%d) = %v, want %v", tt.year, got, tt.want)
+		}
+	}
+}
+
+func TestPrecessEcliptic(t *testing.T) {
+	// Meeus, example 21.c: Venus from J2000 to -214 June 30 0h TD
+	lon, lat := precessEcliptic(149.48194*rad, 1.76549*rad, (1643074.5-J2000)/36525)
+	if got := math.Mod(lon/rad+720, 360); math.Abs(got-118.704) > 0.001 {
+		t.Errorf("precessEcliptic() longitude = %v, want 118.704", got)
+	}
+	if got := lat / rad; math.Abs(got-1.615) > 0.001 {
+		t.Errorf("precessEcliptic() latitude = %v, want 1.615", got)
+	}
+}
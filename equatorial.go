@@ -0,0 +1,66 @@
+package suncalc
+
+import (
+	"math"
+	"time"
+)
+
+const astronomicalUnit = 149597870.7 // km
+
+// EquatorialCoordinates of a body, seen from the center of the Earth
+type EquatorialCoordinates struct {
+	// Right ascension and declination, in radians
+	RightAscension float64
+	Declination    float64
+
+	// Distance from the center of the Earth, in km
+	Distance float64
+}
+
+// calculates the geocentric coordinates of the sun for a given date, with the same model as GetPosition
+func GetSunCoordinates(date time.Time) EquatorialCoordinates {
+	d := toDays(date)
+	c := sunCoords(trueEquinox(d))
+	return EquatorialCoordinates{c.rightAscension, c.declination, sunDistance(d) * astronomicalUnit}
+}
+
+// calculates the geocentric coordinates of the moon for a given date, with the same model as GetMoonPosition
+func GetMoonCoordinates(date time.Time) EquatorialCoordinates {
+	c := moonCoords(trueEquinox(toDays(date)))
+	return EquatorialCoordinates{c.rightAscension, c.declination, c.distance}
+}
+
+// calculates the apparent geocentric coordinates of the sun for a given date, with the accurate model
+// used for the eclipses (chapter 25 of "Astronomical Algorithms"), to a few seconds of arc
+func GetAccurateSunCoordinates(date time.Time) EquatorialCoordinates {
+	sun, _ := accurateSunMoon(date)
+	return sun
+}
+
+// calculates the apparent geocentric coordinates of the moon for a given date, with the accurate model
+// used for the eclipses (chapter 47 of "Astronomical Algorithms"), to about ten seconds of arc
+func GetAccurateMoonCoordinates(date time.Time) EquatorialCoordinates {
+	_, moon := accurateSunMoon(date)
+	return moon
+}
+
+// calculates the Greenwich apparent sidereal time for a given date, in radians between 0 and 2 PI:
+// the hour angle of a body at a longitude is this time plus the longitude minus its right ascension
+func GetSiderealTime(date time.Time) float64 {
+	theta := math.Mod(siderealTime(toDays(date), 0), 2*math.Pi)
+	if theta < 0 {
+		theta += 2 * math.Pi
+	}
+	return theta
+}
+
+// calculates the true obliquity of the ecliptic for a given date, in radians, as used for the equatorial coordinates
+func GetObliquity(date time.Time) float64 {
+	_, e := trueObliquity(toDays(date))
+	return e
+}
+
+// calculates the dip of the horizon seen from a height in meters, in radians, as used for the sun times
+func HorizonDip(height float64) float64 {
+	return -observerAngle(height) * rad
+}
@@ -0,0 +1,71 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetSunCoordinates(t *testing.T) {
+	date := time.Date(2013, 3, 5, 0, 0, 0, 0, time.UTC)
+	lat, lng := 50.5, 30.5
+
+	// the hour angle from the sidereal time gives the same position as GetPosition
+	c := GetSunCoordinates(date)
+	H := GetSiderealTime(date) + lng*rad - c.RightAscension
+	pos := GetPosition(date, lat, lng)
+	if got := altitude(H, lat*rad, c.Declination); math.Abs(got-pos.Altitude) > 1e-9 {
+		t.Errorf("altitude from GetSunCoordinates() = %v, want %v", got, pos.Altitude)
+	}
+	if c.Distance < 147e6 || c.Distance > 149e6 {
+		t.Errorf("GetSunCoordinates() distance = %v, want about 148 million km in March", c.Distance)
+	}
+
+	m := GetMoonCoordinates(date)
+	if want := moonCoords(trueEquinox(toDays(date))); m.RightAscension != want.rightAscension || m.Declination != want.declination || m.Distance != want.distance {
+		t.Errorf("GetMoonCoordinates() = %+v, want %+v", m, want)
+	}
+}
+
+func TestGetSiderealTime(t *testing.T) {
+	// Meeus, examples 12.a, 12.b and 22.a, with the nutation of the date
+	tests := []struct {
+		date time.Time
+		want float64
+	}{
+		{time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC), 13*3600 + 10*60 + 46.1351},
+		{time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC), 8*3600 + 34*60 + 56.853},
+	}
+	for _, tt := range tests {
+		if got := GetSiderealTime(tt.date) / rad * 240; math.Abs(got-tt.want) > 0.05 {
+			t.Errorf("GetSiderealTime(%v) = %vs, want %vs", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestHorizonDip(t *testing.T) {
+	if got := HorizonDip(10) / rad * 60; math.Abs(got-6.565) > 0.001 {
+		t.Errorf("HorizonDip(10) = %v', want 6.565'", got)
+	}
+	if got := HorizonDip(0); got != 0 {
+		t.Errorf("HorizonDip(0) = %v, want 0", got)
+	}
+}
+
+func TestGetSunCoordinatesOfDate(t *testing.T) {
+	// Meeus, example 25.a: apparent place on 1992-10-13 0h TD, about one minute after 0h UT
+	c := GetSunCoordinates(time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC))
+	if got := math.Mod(c.RightAscension/rad+360, 360); math.Abs(got-198.38083)*3600 > 10 {
+		t.Errorf("GetSunCoordinates() right ascension = %v, want 198.38083", got)
+	}
+	if got := c.Declination / rad; math.Abs(got+7.78507)*3600 > 10 {
+		t.Errorf("GetSunCoordinates() declination = %v, want -7.78507", got)
+	}
+}
+
+func TestGetObliquity(t *testing.T) {
+	// Meeus, example 22.a: true obliquity 23°26'36.850" on 1987-04-10
+	if got := GetObliquity(time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC)) / rad; math.Abs(got-23.4435694) > 0.00003 {
+		t.Errorf("GetObliquity() = %v, want 23.4435694", got)
+	}
+}
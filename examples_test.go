@@ -52,21 +52,21 @@ func ExampleGetTimes() {
 	fmt.Printf("Sun Azimuth: %f deg\n", sunPos.Azimuth*180/math.Pi)
 	fmt.Printf("Sun Altitude: %f deg\n", sunPos.Altitude*180/math.Pi)
 	// Output:
-	// nauticalDawn  2005-06-01 01:57:31
-	// dawn          2005-06-01 03:04:43
-	// sunrise       2005-06-01 03:50:12
-	// sunriseEnd    2005-06-01 03:54:33
-	// goldenHourEnd 2005-06-01 04:42:52
-	// goldenHour    2005-06-01 19:16:35
-	// sunsetStart   2005-06-01 20:04:54
-	// sunset        2005-06-01 20:09:15
-	// dusk          2005-06-01 20:54:44
-	// nauticalDusk  2005-06-01 22:01:56
-	// Sunrise / Sunset time: 03:50:12 / 20:09:15
-	// Sunrise Azimuth: -128.374196 deg
-	// Sunset Azimuth: 128.610491 deg
-	// Sun Azimuth: 0.351955 deg
-	// Sun Altitude: 60.593709 deg
+	// nauticalDawn  2005-06-01 01:56:37
+	// dawn          2005-06-01 03:03:31
+	// sunrise       2005-06-01 03:48:56
+	// sunriseEnd    2005-06-01 03:53:16
+	// goldenHourEnd 2005-06-01 04:41:31
+	// goldenHour    2005-06-01 19:15:24
+	// sunsetStart   2005-06-01 20:03:48
+	// sunset        2005-06-01 20:08:09
+	// dusk          2005-06-01 20:53:46
+	// nauticalDusk  2005-06-01 22:01:18
+	// Sunrise / Sunset time: 03:48:56 / 20:08:09
+	// Sunrise Azimuth: -128.429876 deg
+	// Sunset Azimuth: 128.602458 deg
+	// Sun Azimuth: 0.833098 deg
+	// Sun Altitude: 60.605095 deg
 }
 
 func ExampleGetTimesWithObserver() {
@@ -120,21 +120,21 @@ func ExampleGetTimesWithObserver() {
 	fmt.Printf("Sun Azimuth: %f deg\n", sunPos.Azimuth*180/math.Pi)
 	fmt.Printf("Sun Altitude: %f deg\n", sunPos.Altitude*180/math.Pi)
 	// Output:
-	// nightEnd      2012-12-12 05:54:58
-	// nauticalDawn  2012-12-12 06:35:38
-	// dawn          2012-12-12 07:18:37
-	// sunrise       2012-12-12 07:58:39
-	// sunriseEnd    2012-12-12 08:03:00
-	// goldenHourEnd 2012-12-12 08:59:36
-	// goldenHour    2012-12-12 14:51:47
-	// sunsetStart   2012-12-12 15:48:23
-	// sunset        2012-12-12 15:52:45
-	// dusk          2012-12-12 16:32:47
-	// nauticalDusk  2012-12-12 17:15:46
-	// night         2012-12-12 17:56:26
-	// Sunrise / Sunset time: 07:58:39 / 15:52:45
-	// Sunrise Azimuth: -52.101764 deg
-	// Sunset Azimuth: 52.356679 deg
-	// Sun Azimuth: 1.187845 deg
-	// Sun Altitude: 15.381733 deg
+	// nightEnd      2012-12-12 05:53:24
+	// nauticalDawn  2012-12-12 06:34:05
+	// dawn          2012-12-12 07:17:06
+	// sunrise       2012-12-12 07:57:09
+	// sunriseEnd    2012-12-12 08:01:31
+	// goldenHourEnd 2012-12-12 08:58:09
+	// goldenHour    2012-12-12 14:50:17
+	// sunsetStart   2012-12-12 15:46:56
+	// sunset        2012-12-12 15:51:17
+	// dusk          2012-12-12 16:31:21
+	// nauticalDusk  2012-12-12 17:14:21
+	// night         2012-12-12 17:55:02
+	// Sunrise / Sunset time: 07:57:09 / 15:51:17
+	// Sunrise Azimuth: -52.247382 deg
+	// Sunset Azimuth: 52.204712 deg
+	// Sun Azimuth: 1.356737 deg
+	// Sun Altitude: 15.367639 deg
 }
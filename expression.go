@@ -0,0 +1,299 @@
+package suncalc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrNoSuchTime is returned when an expression refers to a sun time that does not occur
+// on that day, e.g. the night in high latitudes during summer
+var ErrNoSuchTime = errors.New("suncalc: the sun time does not occur on that day")
+
+// calculates the n-th temporal (seasonal) hour of the observer's local day: hours 1 to 12 split
+// the day from sunrise to sunset in twelve, hours 13 to 24 the night from sunset to the next sunrise
+func GetTemporalHour(date time.Time, obs Observer, n int) (Interval, error) {
+	if n < 1 || n > 24 {
+		return Interval{}, fmt.Errorf("suncalc: temporal hour %d out of range 1 to 24", n)
+	}
+
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, obs.Location)
+	today := GetTimesWithObserver(noon, obs)
+	start, end := today[Sunrise].Value, today[Sunset].Value
+	if n > 12 {
+		start, end = end, GetTimesWithObserver(noon.AddDate(0, 0, 1), obs)[Sunrise].Value
+		n -= 12
+	}
+	if start.IsZero() || end.IsZero() {
+		return Interval{}, ErrNoSuchTime
+	}
+
+	hour := end.Sub(start) / 12
+	return Interval{start.Add(time.Duration(n-1) * hour), start.Add(time.Duration(n) * hour)}, nil
+}
+
+// SunExpression is a time relative to the sun times, parsed by ParseSunExpression
+type SunExpression struct {
+	source string
+	root   exprNode
+}
+
+// parses a sun-relative time expression, made of:
+//
+//   - sun times names, like "sunrise" or "goldenHour" (see DayTimeNames, plus "solarNoon" and "nadir")
+//   - local clock times, like "21:00" or "06:30:15"
+//   - offsets added or subtracted, like "sunset+30m", "sunrise+00:45" or "dusk-1h15m"
+//   - the earliest or latest of several expressions, like "min(dusk, 21:00)" or "max(sunrise+1h, 07:00)"
+//   - the start of the n-th temporal hour, like "hour(3)" (see GetTemporalHour)
+func ParseSunExpression(s string) (*SunExpression, error) {
+	p := &exprParser{source: s, tokens: tokenize(s)}
+	root, err := p.expression()
+	if err == nil && p.pos < len(p.tokens) {
+		err = p.errorf("unexpected %q", p.tokens[p.pos])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &SunExpression{s, root}, nil
+}
+
+func (e *SunExpression) String() string {
+	return e.source
+}
+
+// calculates the time of the expression for the observer's local day of the given date
+func (e *SunExpression) Evaluate(date time.Time, obs Observer) (time.Time, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, obs.Location)
+	ctx := &exprContext{day: day, obs: obs}
+	return e.root.eval(ctx)
+}
+
+type exprContext struct {
+	day   time.Time
+	obs   Observer
+	times map[DayTimeName]DayTime
+}
+
+// the sun times are calculated once, when first needed
+func (ctx *exprContext) sunTime(name DayTimeName) (time.Time, error) {
+	if ctx.times == nil {
+		ctx.times = GetTimesWithObserver(ctx.day.Add(12*time.Hour), ctx.obs)
+	}
+	t := ctx.times[name].Value
+	if t.IsZero() {
+		return t, ErrNoSuchTime
+	}
+	return t, nil
+}
+
+type exprNode interface {
+	eval(ctx *exprContext) (time.Time, error)
+}
+
+type sunTimeNode DayTimeName
+
+func (n sunTimeNode) eval(ctx *exprContext) (time.Time, error) {
+	return ctx.sunTime(DayTimeName(n))
+}
+
+type clockNode time.Duration // since midnight
+
+func (n clockNode) eval(ctx *exprContext) (time.Time, error) {
+	d := time.Duration(n)
+	return time.Date(ctx.day.Year(), ctx.day.Month(), ctx.day.Day(), int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second), 0, ctx.obs.Location), nil
+}
+
+type offsetNode struct {
+	operand exprNode
+	offset  time.Duration
+}
+
+func (n offsetNode) eval(ctx *exprContext) (time.Time, error) {
+	t, err := n.operand.eval(ctx)
+	return t.Add(n.offset), err
+}
+
+type extremumNode struct {
+	latest   bool
+	operands []exprNode
+}
+
+func (n extremumNode) eval(ctx *exprContext) (time.Time, error) {
+	var result time.Time
+	for i, operand := range n.operands {
+		t, err := operand.eval(ctx)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if i == 0 || (n.latest && t.After(result)) || (!n.latest && t.Before(result)) {
+			result = t
+		}
+	}
+	return result, nil
+}
+
+type temporalHourNode int
+
+func (n temporalHourNode) eval(ctx *exprContext) (time.Time, error) {
+	hour, err := GetTemporalHour(ctx.day, ctx.obs, int(n))
+	return hour.Start, err
+}
+
+// splits the expression into names, numbers (clock times and durations) and punctuation
+func tokenize(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		r := rune(s[i])
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			j := i
+			for j < len(s) && (unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j])) || s[j] == ':' || s[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			tokens = append(tokens, s[i:i+1])
+			i++
+		}
+	}
+	return tokens
+}
+
+type exprParser struct {
+	source string
+	tokens []string
+	pos    int
+}
+
+func (p *exprParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("suncalc: expression %q: %s", p.source, fmt.Sprintf(format, a...))
+}
+
+func (p *exprParser) next() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	p.pos++
+	return p.tokens[p.pos-1]
+}
+
+func (p *exprParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) expect(token string) error {
+	if got := p.next(); got != token {
+		return p.errorf("expected %q, got %q", token, got)
+	}
+	return nil
+}
+
+// expression := operand { ("+" | "-") duration }
+func (p *exprParser) expression() (exprNode, error) {
+	node, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "+" || p.peek() == "-" {
+		sign := time.Duration(1)
+		if p.next() == "-" {
+			sign = -1
+		}
+		d, err := parseExprDuration(p.next())
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		node = offsetNode{node, sign * d}
+	}
+	return node, nil
+}
+
+// operand := name | clock | "min(" expressions ")" | "max(" expressions ")" | "hour(" number ")"
+func (p *exprParser) operand() (exprNode, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, p.errorf("unexpected end")
+	case unicode.IsDigit(rune(token[0])):
+		d, err := parseClock(token)
+		if err != nil || d >= 24*time.Hour {
+			return nil, p.errorf("invalid clock time %q", token)
+		}
+		return clockNode(d), nil
+	case p.peek() == "(":
+		return p.call(token)
+	}
+
+	for _, name := range append([]DayTimeName{SolarNoon, Nadir}, DayTimeNames...) {
+		if strings.EqualFold(token, string(name)) {
+			return sunTimeNode(name), nil
+		}
+	}
+	return nil, p.errorf("unknown sun time %q", token)
+}
+
+func (p *exprParser) call(function string) (exprNode, error) {
+	p.next() // (
+	switch strings.ToLower(function) {
+	case "min", "max":
+		node := extremumNode{latest: strings.ToLower(function) == "max"}
+		for {
+			operand, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			node.operands = append(node.operands, operand)
+			if p.peek() != "," {
+				break
+			}
+			p.next()
+		}
+		return node, p.expect(")")
+	case "hour":
+		n, err := strconv.Atoi(p.next())
+		if err != nil || n < 1 || n > 24 {
+			return nil, p.errorf("temporal hour must be a number from 1 to 24")
+		}
+		return temporalHourNode(n), p.expect(")")
+	}
+	return nil, p.errorf("unknown function %q", function)
+}
+
+// parses "HH:MM" or "HH:MM:SS"
+func parseClock(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid clock %q", s)
+	}
+	var d time.Duration
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 || (i > 0 && v >= 60) {
+			return 0, fmt.Errorf("invalid clock %q", s)
+		}
+		d += time.Duration(v) * units[i]
+	}
+	return d, nil
+}
+
+// parses "HH:MM", "HH:MM:SS" or a Go duration like "1h30m"
+func parseExprDuration(s string) (time.Duration, error) {
+	if strings.Contains(s, ":") {
+		return parseClock(s)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
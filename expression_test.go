@@ -0,0 +1,94 @@
+package suncalc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSunExpressionEvaluate(t *testing.T) {
+	paris := time.FixedZone("CEST", 2*60*60)
+	obs := Observer{Latitude: 48.85, Longitude: 2.35, Location: paris}
+	date := time.Date(2021, 6, 21, 8, 0, 0, 0, paris)
+	times := GetTimesWithObserver(time.Date(2021, 6, 21, 12, 0, 0, 0, paris), obs)
+	hour3, _ := GetTemporalHour(date, obs, 3)
+
+	tests := []struct {
+		expression string
+		want       time.Time
+	}{
+		{"sunset", times[Sunset].Value},
+		{"sunset + 30m", times[Sunset].Value.Add(30 * time.Minute)},
+		{"sunrise+00:45", times[Sunrise].Value.Add(45 * time.Minute)},
+		{"goldenHour-10m", times[GoldenHour].Value.Add(-10 * time.Minute)},
+		{"dusk-1h15m+5s", times[Dusk].Value.Add(-time.Hour - 15*time.Minute + 5*time.Second)},
+		{"SolarNoon", times[SolarNoon].Value},
+		{"21:00", time.Date(2021, 6, 21, 21, 0, 0, 0, paris)},
+		{"06:30:15", time.Date(2021, 6, 21, 6, 30, 15, 0, paris)},
+		{"min(dusk, 21:00)", time.Date(2021, 6, 21, 21, 0, 0, 0, paris)},
+		{"max(dusk, 21:00)", times[Dusk].Value},
+		{"min(sunrise+1h, 07:00, dawn)", times[Dawn].Value},
+		{"hour(3)", hour3.Start},
+		{"hour(3) + 10m", hour3.Start.Add(10 * time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, err := ParseSunExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseSunExpression() error = %v", err)
+			}
+			got, err := expr.Evaluate(date, obs)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSunExpressionErrors(t *testing.T) {
+	for _, s := range []string{"", "noon", "sunset +", "sunset + later", "sunset 10m", "25:00", "min(dusk", "min()", "hour(25)", "avg(dusk, dawn)", "sunset + -10m"} {
+		if _, err := ParseSunExpression(s); err == nil {
+			t.Errorf("ParseSunExpression(%q) error = nil, want an error", s)
+		}
+	}
+}
+
+func TestSunExpressionNoSuchTime(t *testing.T) {
+	obs := Observer{Latitude: 78.2, Longitude: 15.6, Location: time.UTC}
+	expr, _ := ParseSunExpression("max(sunset, 22:00)")
+	if _, err := expr.Evaluate(time.Date(2021, 6, 21, 0, 0, 0, 0, time.UTC), obs); !errors.Is(err, ErrNoSuchTime) {
+		t.Errorf("Evaluate() error = %v, want ErrNoSuchTime", err)
+	}
+}
+
+func TestGetTemporalHour(t *testing.T) {
+	obs := Observer{Latitude: 31.78, Longitude: 35.22, Location: time.FixedZone("IST", 2*60*60)}
+	date := time.Date(2021, 12, 21, 0, 0, 0, 0, obs.Location)
+	times := GetTimesWithObserver(date.Add(12*time.Hour), obs)
+	nextSunrise := GetTimesWithObserver(date.Add(36*time.Hour), obs)[Sunrise].Value
+
+	first, _ := GetTemporalHour(date, obs, 1)
+	twelfth, _ := GetTemporalHour(date, obs, 12)
+	if !first.Start.Equal(times[Sunrise].Value) || twelfth.End.Sub(times[Sunset].Value) > time.Microsecond {
+		t.Errorf("GetTemporalHour() day = %v to %v, want %v to %v", first.Start, twelfth.End, times[Sunrise].Value, times[Sunset].Value)
+	}
+	if want := times[Sunset].Value.Sub(times[Sunrise].Value) / 12; first.Duration() != want {
+		t.Errorf("GetTemporalHour() duration = %v, want %v", first.Duration(), want)
+	}
+
+	night, _ := GetTemporalHour(date, obs, 13)
+	last, _ := GetTemporalHour(date, obs, 24)
+	if !night.Start.Equal(times[Sunset].Value) || nextSunrise.Sub(last.End) > time.Microsecond {
+		t.Errorf("GetTemporalHour() night = %v to %v, want %v to %v", night.Start, last.End, times[Sunset].Value, nextSunrise)
+	}
+	if night.Duration() <= first.Duration() {
+		t.Errorf("GetTemporalHour() night hour %v, want longer than the day hour %v in winter", night.Duration(), first.Duration())
+	}
+
+	if _, err := GetTemporalHour(date, obs, 0); err == nil {
+		t.Errorf("GetTemporalHour(0) error = nil, want an error")
+	}
+}
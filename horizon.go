@@ -0,0 +1,162 @@
+package suncalc
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HorizonPoint is one sample of a horizon profile: the elevation (in radians) of the
+// terrain seen by the observer in the direction of the given azimuth (in radians,
+// measured from south to west, like SunPosition.Azimuth)
+type HorizonPoint struct {
+	Azimuth  float64
+	Altitude float64
+}
+
+// HorizonProfile describes the skyline around an observer (mountains, buildings, ...).
+// Elevations between two points are linearly interpolated.
+type HorizonProfile []HorizonPoint
+
+// NewHorizonProfile builds a horizon profile from the given points, in any order
+func NewHorizonProfile(points []HorizonPoint) HorizonProfile {
+	profile := make(HorizonProfile, len(points))
+	for i, p := range points {
+		profile[i] = HorizonPoint{normalizeAzimuth(p.Azimuth), p.Altitude}
+	}
+	sort.Slice(profile, func(i, j int) bool { return profile[i].Azimuth < profile[j].Azimuth })
+	return profile
+}
+
+// ReadHorizonProfile reads a horizon profile from CSV records "azimuth,elevation".
+// Both values are in degrees, the azimuth being measured clockwise from north as
+// exported by most horizon tools. An optional header line and '#' comments are ignored.
+func ReadHorizonProfile(r io.Reader) (HorizonProfile, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var points []HorizonPoint
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("suncalc: horizon record %d: expected azimuth and elevation", line)
+		}
+
+		az, errAz := strconv.ParseFloat(strings.TrimSpace(record[0]), 64)
+		alt, errAlt := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if errAz != nil || errAlt != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("suncalc: horizon record %d: invalid number", line)
+		}
+
+		points = append(points, HorizonPoint{(az - 180) * rad, alt * rad})
+	}
+
+	return NewHorizonProfile(points), nil
+}
+
+// Altitude returns the elevation of the horizon, in radians, for the given azimuth
+func (p HorizonProfile) Altitude(azimuth float64) float64 {
+	if len(p) == 0 {
+		return 0
+	}
+	az := normalizeAzimuth(azimuth)
+
+	// first point east of (i.e. with an azimuth greater than) the requested one
+	i := sort.Search(len(p), func(i int) bool { return p[i].Azimuth > az })
+	prev, next := p[(i+len(p)-1)%len(p)], p[i%len(p)]
+
+	width := azimuthSpan(prev.Azimuth, next.Azimuth)
+	if width == 0 {
+		return prev.Altitude
+	}
+	x := azimuthSpan(prev.Azimuth, az) / width
+	return prev.Altitude + x*(next.Altitude-prev.Altitude)
+}
+
+// returns the azimuth in the range [-PI, PI)
+func normalizeAzimuth(a float64) float64 {
+	a = math.Mod(a+math.Pi, 2*math.Pi)
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+	return a - math.Pi
+}
+
+// returns the angle swept eastward from azimuth a to azimuth b, in the range [0, 2*PI)
+func azimuthSpan(a float64, b float64) float64 {
+	return normalizeAzimuth(b-a-math.Pi) + math.Pi
+}
+
+// returns the altitude of the observer's horizon in the given azimuth: the horizon
+// profile if any, otherwise the mathematical horizon lowered by the observer height
+func (obs Observer) horizonAltitude(azimuth float64) float64 {
+	if len(obs.Horizon) == 0 {
+		return observerAngle(obs.Height) * rad
+	}
+	return obs.Horizon.Altitude(azimuth)
+}
+
+type HorizonTimes struct {
+	Rise       time.Time
+	Set        time.Time
+	AlwaysUp   bool
+	AlwaysDown bool
+}
+
+// step used to look for horizon crossings, narrower terrain features may be missed
+const horizonStep = 5 * time.Minute
+
+// calculates the sun rise and set times over the observer's horizon profile for the
+// observer's local day: the first instant the top edge of the sun clears the skyline,
+// and the last instant it disappears behind it. Same as GetBodyTimes for the Sun
+func GetHorizonTimes(date time.Time, obs Observer) HorizonTimes {
+	return GetBodyTimes(date, obs, Sun).horizonTimes()
+}
+
+// calculates the moon rise and set times over the observer's horizon profile for the
+// observer's local day. Same as GetBodyTimes for the Moon
+func GetMoonHorizonTimes(date time.Time, obs Observer) HorizonTimes {
+	return GetBodyTimes(date, obs, Moon).horizonTimes()
+}
+
+func horizonTimes(date time.Time, obs Observer, f func(time.Time) float64) HorizonTimes {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, obs.Location)
+	end := start.AddDate(0, 0, 1)
+
+	var result HorizonTimes
+	crossings := findCrossings(start, end, horizonStep, f)
+	for _, c := range crossings {
+		if c.rising && result.Rise.IsZero() {
+			result.Rise = c.time
+		}
+		if !c.rising {
+			result.Set = c.time
+		}
+	}
+
+	if len(crossings) == 0 {
+		if f(start) > 0 {
+			result.AlwaysUp = true
+		} else {
+			result.AlwaysDown = true
+		}
+	}
+
+	return result
+}
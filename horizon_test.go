@@ -0,0 +1,109 @@
+package suncalc
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHorizonProfileAltitude(t *testing.T) {
+	profile := NewHorizonProfile([]HorizonPoint{
+		{170 * rad, 10 * rad},
+		{-90 * rad, 20 * rad},
+		{0, 0},
+		{90 * rad, 5 * rad},
+	})
+
+	tests := []struct {
+		name    string
+		azimuth float64
+		want    float64
+	}{
+		{"on a point", -90 * rad, 20 * rad},
+		{"between two points", -45 * rad, 10 * rad},
+		{"across north", 180 * rad, 11 * rad},
+		{"across north, negative", -175 * rad, 11.5 * rad},
+		{"full turn", 360 * rad, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := profile.Altitude(tt.azimuth); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Altitude() = %v, want %v", got/rad, tt.want/rad)
+			}
+		})
+	}
+}
+
+func TestReadHorizonProfile(t *testing.T) {
+	csv := "azimuth,elevation\n# surveyed 2020-05-17\n0,2\n90, 15\n180,0\n270,4.5\n"
+	profile, err := ReadHorizonProfile(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ReadHorizonProfile() error = %v", err)
+	}
+	if len(profile) != 4 {
+		t.Fatalf("ReadHorizonProfile() read %d points, want 4", len(profile))
+	}
+	// east is -90 degrees from south
+	if got := profile.Altitude(-90 * rad); math.Abs(got-15*rad) > 1e-9 {
+		t.Errorf("Altitude(east) = %v, want 15", got/rad)
+	}
+
+	if _, err := ReadHorizonProfile(strings.NewReader("0,2\n90,high\n")); err == nil {
+		t.Errorf("ReadHorizonProfile() expected an error on an invalid elevation")
+	}
+}
+
+func TestGetHorizonTimes(t *testing.T) {
+	date := time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC)
+	obs := Observer{Latitude: 50.700078, Longitude: 2.891449, Location: time.UTC}
+
+	// both use the solver of GetBodyTimes, to its resolution
+	const tolerance = time.Second
+	times := GetTimesWithObserver(date.Add(12*time.Hour), obs)
+	flat := GetHorizonTimes(date, obs)
+	if d := flat.Rise.Sub(times[Sunrise].Value); d < -tolerance || d > tolerance {
+		t.Errorf("Rise = %v, want %v", flat.Rise, times[Sunrise].Value)
+	}
+	if d := flat.Set.Sub(times[Sunset].Value); d < -tolerance || d > tolerance {
+		t.Errorf("Set = %v, want %v", flat.Set, times[Sunset].Value)
+	}
+
+	// a 10 degrees high ridge on the eastern side of the valley
+	obs.Horizon = NewHorizonProfile([]HorizonPoint{{-150 * rad, 10 * rad}, {-60 * rad, 10 * rad}, {-30 * rad, 0}, {150 * rad, 0}})
+	valley := GetHorizonTimes(date, obs)
+	if !valley.Rise.After(flat.Rise.Add(time.Hour)) {
+		t.Errorf("Rise = %v, want more than an hour after %v", valley.Rise, flat.Rise)
+	}
+	if d := valley.Set.Sub(flat.Set); d < -2*time.Second || d > 2*time.Second {
+		t.Errorf("Set = %v, want %v", valley.Set, flat.Set)
+	}
+
+	if body := GetBodyTimes(date, obs, Sun); !body.Rise.Equal(valley.Rise) || !body.Set.Equal(valley.Set) {
+		t.Errorf("GetBodyTimes(Sun) = %v, %v, want %v, %v", body.Rise, body.Set, valley.Rise, valley.Set)
+	}
+
+	polar := GetHorizonTimes(time.Date(2020, 6, 21, 0, 0, 0, 0, time.UTC), Observer{Latitude: 80, Location: time.UTC})
+	if !polar.AlwaysUp || !polar.Rise.IsZero() {
+		t.Errorf("GetHorizonTimes() = %v, want always up", polar)
+	}
+}
+
+func TestGetMoonHorizonTimes(t *testing.T) {
+	date := time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC)
+	obs := Observer{Latitude: 50.700078, Longitude: 2.891449, Location: time.UTC}
+
+	// the moon times share the rise and set solver of the bodies
+	flat := GetMoonHorizonTimes(date, obs)
+	moon := GetMoonTimesWithObserver(date, obs)
+	body := GetBodyTimes(date, obs, Moon)
+	if !flat.Rise.Equal(moon.Rise) || !flat.Set.Equal(moon.Set) || !flat.Rise.Equal(body.Rise) || !flat.Set.Equal(body.Set) {
+		t.Errorf("GetMoonHorizonTimes() = %v, GetMoonTimesWithObserver() = %v, GetBodyTimes() = %v, want the same times", flat, moon, body)
+	}
+
+	obs.Horizon = NewHorizonProfile([]HorizonPoint{{0, 5 * rad}})
+	raised := GetMoonHorizonTimes(date, obs)
+	if !raised.Rise.After(flat.Rise) || !raised.Set.Before(flat.Set) {
+		t.Errorf("GetMoonHorizonTimes() = %v, want a shorter visibility than %v", raised, flat)
+	}
+}
@@ -0,0 +1,49 @@
+// Package sphere holds the rotations between the ecliptic, equatorial and horizontal coordinate
+// systems (chapters 13 and 14 of "Astronomical Algorithms"), shared by suncalc and its coord package.
+//
+// All the angles are in radians, and the azimuth is measured from the south towards the west.
+package sphere
+
+import "math"
+
+// converts ecliptic coordinates to a right ascension and a declination for an obliquity of the ecliptic
+// (formulas 13.3 and 13.4)
+func EclipticToEquatorial(lon float64, lat float64, obliquity float64) (float64, float64) {
+	sinE, cosE := math.Sincos(obliquity)
+	ra := math.Atan2(math.Sin(lon)*cosE-math.Tan(lat)*sinE, math.Cos(lon))
+	dec := math.Asin(math.Sin(lat)*cosE + math.Cos(lat)*sinE*math.Sin(lon))
+	return ra, dec
+}
+
+// converts a right ascension and a declination to ecliptic coordinates for an obliquity of the ecliptic
+// (formulas 13.1 and 13.2)
+func EquatorialToEcliptic(ra float64, dec float64, obliquity float64) (float64, float64) {
+	sinE, cosE := math.Sincos(obliquity)
+	lon := math.Atan2(math.Sin(ra)*cosE+math.Tan(dec)*sinE, math.Cos(ra))
+	lat := math.Asin(math.Sin(dec)*cosE - math.Cos(dec)*sinE*math.Sin(ra))
+	return lon, lat
+}
+
+// returns the azimuth of a body of hour angle H and declination dec seen from the latitude phi (formula 13.5)
+func Azimuth(H float64, phi float64, dec float64) float64 {
+	return math.Atan2(math.Sin(H), math.Cos(H)*math.Sin(phi)-math.Tan(dec)*math.Cos(phi))
+}
+
+// returns the altitude of a body of hour angle H and declination dec seen from the latitude phi,
+// without refraction (formula 13.6)
+func Altitude(H float64, phi float64, dec float64) float64 {
+	return math.Asin(math.Sin(phi)*math.Sin(dec) + math.Cos(phi)*math.Cos(dec)*math.Cos(H))
+}
+
+// converts the azimuth and the altitude of a body seen from the latitude phi to its hour angle and declination
+func HorizontalToEquatorial(az float64, h float64, phi float64) (float64, float64) {
+	H := math.Atan2(math.Sin(az), math.Cos(az)*math.Sin(phi)+math.Tan(h)*math.Cos(phi))
+	dec := math.Asin(math.Sin(phi)*math.Sin(h) - math.Cos(phi)*math.Cos(h)*math.Cos(az))
+	return H, dec
+}
+
+// returns the parallactic angle of a body of hour angle H and declination dec seen from the latitude phi:
+// the angle between the direction of the zenith and the one of the north celestial pole (formula 14.1)
+func ParallacticAngle(H float64, phi float64, dec float64) float64 {
+	return math.Atan2(math.Sin(H), math.Tan(phi)*math.Cos(dec)-math.Sin(dec)*math.Cos(H))
+}
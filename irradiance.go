@@ -0,0 +1,126 @@
+package suncalc
+
+import (
+	"math"
+	"time"
+)
+
+// total solar irradiance at one astronomical unit, in W/m²
+const solarConstant = 1361.0
+
+// returns the distance from the Earth to the Sun, in astronomical units
+func sunDistance(d float64) float64 {
+	M := solarMeanAnomalyF(d)
+	return 1.00014 - 0.01671*math.Cos(M) - 0.00014*math.Cos(2*M)
+}
+
+// Irradiance components, in W/m²
+type Irradiance struct {
+	GHI float64 // global horizontal irradiance
+	DNI float64 // direct normal irradiance
+	DHI float64 // diffuse horizontal irradiance
+}
+
+// calculates the solar irradiance at the top of the atmosphere, normal to the sun rays,
+// from the Earth-Sun distance, in W/m²
+func GetExtraterrestrialIrradiance(date time.Time) float64 {
+	r := sunDistance(toDays(date))
+	return solarConstant / (r * r)
+}
+
+// calculates the relative optical air mass for the given sun altitude, in radians,
+// based on F. Kasten and A. T. Young, "Revised optical air mass tables and approximation formula" (1989).
+// The air mass is infinite when the sun is below the horizon.
+func AirMass(altitude float64) float64 {
+	if altitude < 0 {
+		return math.Inf(1)
+	}
+	zenith := 90 - altitude/rad
+	return 1 / (math.Cos(zenith*rad) + 0.50572*math.Pow(96.07995-zenith, -1.6364))
+}
+
+// returns the atmospheric pressure, in Pa, at the given elevation above sea level, in meters
+func pressureAt(elevation float64) float64 {
+	return 101325 * math.Pow(1-2.25577e-5*elevation, 5.25588)
+}
+
+// ClearSkyModel estimates the irradiance under a cloudless sky, for a sun above the horizon
+type ClearSkyModel interface {
+	// altitude of the sun in radians, relative air mass and extraterrestrial irradiance in W/m²
+	ClearSky(altitude float64, airMass float64, extraterrestrial float64) Irradiance
+}
+
+// Haurwitz clear-sky model, only depends on the sun altitude.
+// The global irradiance is split into direct and diffuse components with the Erbs correlation.
+type Haurwitz struct{}
+
+func (Haurwitz) ClearSky(altitude float64, airMass float64, extraterrestrial float64) Irradiance {
+	cosZ := math.Sin(altitude)
+	ghi := 1098 * cosZ * math.Exp(-0.059/cosZ)
+	return erbs(ghi, cosZ, extraterrestrial)
+}
+
+// splits the global horizontal irradiance into its direct and diffuse components,
+// based on D. G. Erbs, S. A. Klein and J. A. Duffie (1982)
+func erbs(ghi float64, cosZ float64, extraterrestrial float64) Irradiance {
+	kt := math.Min(math.Max(ghi/(extraterrestrial*cosZ), 0), 1) // clearness index
+
+	var diffuseFraction float64
+	switch {
+	case kt <= 0.22:
+		diffuseFraction = 1 - 0.09*kt
+	case kt <= 0.8:
+		diffuseFraction = 0.9511 - 0.1604*kt + 4.388*kt*kt - 16.638*kt*kt*kt + 12.336*kt*kt*kt*kt
+	default:
+		diffuseFraction = 0.165
+	}
+
+	dhi := ghi * diffuseFraction
+	return Irradiance{ghi, (ghi - dhi) / cosZ, dhi}
+}
+
+// Ineichen and Perez clear-sky model, based on P. Ineichen and R. Perez,
+// "A new airmass independent formulation for the Linke turbidity coefficient" (2002)
+type Ineichen struct {
+	// Linke turbidity of the atmosphere, about 2 for a very clear sky and 3 to 5 in cities
+	LinkeTurbidity float64
+
+	// Elevation of the site above sea level, in meters. It is not the observer Height, which lowers the horizon
+	Elevation float64
+}
+
+func (m Ineichen) ClearSky(altitude float64, airMass float64, extraterrestrial float64) Irradiance {
+	tl := m.LinkeTurbidity
+	elevation := m.Elevation
+	cosZ := math.Sin(altitude)
+	am := airMass * pressureAt(elevation) / 101325 // absolute air mass
+
+	fh1 := math.Exp(-elevation / 8000)
+	fh2 := math.Exp(-elevation / 1250)
+	cg1 := 5.09e-5*elevation + 0.868
+	cg2 := 3.92e-5*elevation + 0.0387
+
+	ghi := cg1 * extraterrestrial * cosZ * math.Exp(-cg2*am*(fh1+fh2*(tl-1)))
+	ghi = math.Max(ghi, 0)
+
+	b := 0.664 + 0.163/fh1
+	bnci := extraterrestrial * math.Max(b*math.Exp(-0.09*am*(tl-1)), 0)
+	bnci2 := ghi * math.Min(math.Max((1-(0.1-0.2*math.Exp(-tl))/(0.1+0.882/fh1))/cosZ, 0), 1e20)
+	dni := math.Min(bnci, bnci2)
+
+	return Irradiance{ghi, dni, ghi - dni*cosZ}
+}
+
+// calculates the clear-sky irradiance for a given date and observer. The irradiance is zero when
+// the sun is below the horizon.
+func GetClearSkyIrradiance(date time.Time, obs Observer, model ClearSkyModel) Irradiance {
+	return clearSkyIrradiance(date, GetPosition(date, obs.Latitude, obs.Longitude), model)
+}
+
+// same as GetClearSkyIrradiance for the position of the sun at the date
+func clearSkyIrradiance(date time.Time, pos SunPosition, model ClearSkyModel) Irradiance {
+	if pos.Altitude <= 0 {
+		return Irradiance{}
+	}
+	return model.ClearSky(pos.Altitude, AirMass(pos.Altitude), GetExtraterrestrialIrradiance(date))
+}
@@ -0,0 +1,79 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAirMass(t *testing.T) {
+	tests := []struct {
+		altitude float64
+		want     float64
+	}{
+		{90 * rad, 1},
+		{30 * rad, 1.9942},
+		{5 * rad, 10.3058},
+		{0, 37.9196},
+		{-1 * rad, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		if got := AirMass(tt.altitude); math.Abs(got-tt.want) > 1e-3 && !(math.IsInf(got, 1) && math.IsInf(tt.want, 1)) {
+			t.Errorf("AirMass(%v) = %v, want %v", tt.altitude/rad, got, tt.want)
+		}
+	}
+}
+
+func TestGetExtraterrestrialIrradiance(t *testing.T) {
+	// the Earth is closest to the Sun early January and farthest early July
+	perihelion := GetExtraterrestrialIrradiance(time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC))
+	aphelion := GetExtraterrestrialIrradiance(time.Date(2020, 7, 4, 0, 0, 0, 0, time.UTC))
+	if math.Abs(perihelion-1407) > 2 || math.Abs(aphelion-1317) > 2 {
+		t.Errorf("GetExtraterrestrialIrradiance() = %v / %v, want 1407 / 1317", perihelion, aphelion)
+	}
+}
+
+func TestClearSkyModels(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    ClearSkyModel
+		altitude float64
+		want     Irradiance
+	}{
+		{"Haurwitz zenith", Haurwitz{}, 90 * rad, Irradiance{1035.09, 853.72, 181.38}},
+		{"Haurwitz low sun", Haurwitz{}, 10 * rad, Irradiance{135.74, 393.57, 67.40}},
+		{"Ineichen zenith", Ineichen{LinkeTurbidity: 3}, 90 * rad, Irradiance{1051.89, 940.18, 111.71}},
+		{"Ineichen mountain", Ineichen{LinkeTurbidity: 3, Elevation: 2000}, 90 * rad, Irradiance{1184.05, 1032.06, 151.98}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.model.ClearSky(tt.altitude, AirMass(tt.altitude), solarConstant)
+			if math.Abs(got.GHI-tt.want.GHI) > 0.01 || math.Abs(got.DNI-tt.want.DNI) > 0.01 || math.Abs(got.DHI-tt.want.DHI) > 0.01 {
+				t.Errorf("ClearSky() = %+v, want %+v", got, tt.want)
+			}
+			if cosZ := math.Sin(tt.altitude); math.Abs(got.DHI+got.DNI*cosZ-got.GHI) > 1e-9 {
+				t.Errorf("ClearSky() = %+v, components do not add up", got)
+			}
+		})
+	}
+}
+
+func TestGetClearSkyIrradiance(t *testing.T) {
+	obs := Observer{Latitude: 51.5, Longitude: -0.1, Location: time.UTC}
+
+	noon := GetClearSkyIrradiance(time.Date(2005, 6, 1, 12, 0, 0, 0, time.UTC), obs, Ineichen{LinkeTurbidity: 3})
+	if noon.GHI < 800 || noon.GHI > 950 {
+		t.Errorf("GetClearSkyIrradiance() at noon = %+v", noon)
+	}
+
+	night := GetClearSkyIrradiance(time.Date(2005, 6, 1, 0, 0, 0, 0, time.UTC), obs, Ineichen{LinkeTurbidity: 3})
+	if night != (Irradiance{}) {
+		t.Errorf("GetClearSkyIrradiance() at night = %+v, want zero", night)
+	}
+
+	// the height of the observer above the ground only lowers the horizon
+	obs.Height = 1500
+	if got := GetClearSkyIrradiance(time.Date(2005, 6, 1, 12, 0, 0, 0, time.UTC), obs, Ineichen{LinkeTurbidity: 3}); got != noon {
+		t.Errorf("GetClearSkyIrradiance() with a height = %+v, want %+v", got, noon)
+	}
+}
@@ -0,0 +1,149 @@
+package suncalc
+
+import (
+	"math"
+	"time"
+)
+
+type LunarEclipse struct {
+	// PenumbralEclipse, PartialEclipse or TotalEclipse
+	Kind EclipseKind
+
+	// Instant the center of the moon is the closest to the axis of the shadow of the Earth
+	Greatest time.Time
+
+	// Fraction of the diameter of the moon in the penumbra and in the umbra at the greatest eclipse,
+	// negative when the moon does not enter them
+	PenumbralMagnitude float64
+	UmbralMagnitude    float64
+
+	// Contacts with the penumbra (P1, P4), and with the umbra at the beginning and end of the partial (U1, U4)
+	// and total (U2, U3) phases, zero when the phase does not occur
+	P1, U1, U2, U3, U4, P4 time.Time
+}
+
+// shadow holds the geocentric angular distance from the moon to the center of the shadow of the Earth, the
+// radii of the penumbra and of the umbra, enlarged for the atmosphere with the method of Danjon, and the
+// semi-diameter of the moon, in radians
+type shadow struct {
+	distance, penumbra, umbra, moon float64
+}
+
+func shadowAt(date time.Time) shadow {
+	sun, moon := accurateSunMoon(date)
+
+	moonParallax := math.Asin(earthRadiusKm / moon.Distance)
+	sunParallax := math.Asin(earthRadiusKm / sun.Distance)
+	sunRadius := math.Asin(sunRadiusKm / sun.Distance)
+
+	return shadow{
+		distance: angularDistance(sun.RightAscension+math.Pi, -sun.Declination, moon.RightAscension, moon.Declination),
+		penumbra: 1.01*moonParallax + sunParallax + sunRadius,
+		umbra:    1.01*moonParallax + sunParallax - sunRadius,
+		moon:     math.Asin(moonPenumbralRadius * math.Sin(moonParallax)),
+	}
+}
+
+// returns the angle between two directions given by their right ascensions and declinations (Vincenty formula)
+func angularDistance(ra1 float64, dec1 float64, ra2 float64, dec2 float64) float64 {
+	sin1, cos1 := math.Sincos(dec1)
+	sin2, cos2 := math.Sincos(dec2)
+	sinRa, cosRa := math.Sincos(ra2 - ra1)
+	x := cos2 * sinRa
+	y := cos1*sin2 - sin1*cos2*cosRa
+	return math.Atan2(math.Hypot(x, y), sin1*sin2+cos1*cos2*cosRa)
+}
+
+// how far from the greatest eclipse the contacts are looked for
+const lunarEclipseWindow = 4 * time.Hour
+
+// lists the lunar eclipses between start and end
+func GetLunarEclipses(start time.Time, end time.Time) []LunarEclipse {
+	var result []LunarEclipse
+	for _, fullMoon := range findSyzygies(start, end, true) {
+		greatest := findMinimum(fullMoon.Add(-eclipseWindow), fullMoon.Add(eclipseWindow), 10*time.Minute, func(t time.Time) float64 {
+			return shadowAt(t).distance
+		})
+
+		s := shadowAt(greatest)
+		eclipse := LunarEclipse{
+			Greatest:           greatest,
+			PenumbralMagnitude: (s.penumbra + s.moon - s.distance) / (2 * s.moon),
+			UmbralMagnitude:    (s.umbra + s.moon - s.distance) / (2 * s.moon),
+		}
+		switch {
+		case eclipse.UmbralMagnitude >= 1:
+			eclipse.Kind = TotalEclipse
+		case eclipse.UmbralMagnitude > 0:
+			eclipse.Kind = PartialEclipse
+		case eclipse.PenumbralMagnitude > 0:
+			eclipse.Kind = PenumbralEclipse
+		default:
+			continue
+		}
+
+		// entering (falling) and leaving (rising) each shadow
+		contacts := func(radius func(s shadow) float64) (time.Time, time.Time) {
+			var begin, end time.Time
+			for _, c := range findCrossings(greatest.Add(-lunarEclipseWindow), greatest.Add(lunarEclipseWindow), 10*time.Minute, func(t time.Time) float64 {
+				s := shadowAt(t)
+				return s.distance - radius(s)
+			}) {
+				if c.rising {
+					end = c.time
+				} else {
+					begin = c.time
+				}
+			}
+			return begin, end
+		}
+		eclipse.P1, eclipse.P4 = contacts(func(s shadow) float64 { return s.penumbra + s.moon })
+		if eclipse.Kind != PenumbralEclipse {
+			eclipse.U1, eclipse.U4 = contacts(func(s shadow) float64 { return s.umbra + s.moon })
+		}
+		if eclipse.Kind == TotalEclipse {
+			eclipse.U2, eclipse.U3 = contacts(func(s shadow) float64 { return s.umbra - s.moon })
+		}
+		result = append(result, eclipse)
+	}
+	return result
+}
+
+// LocalLunarEclipse holds the altitude of the moon at the contacts of a lunar eclipse seen from an observer
+type LocalLunarEclipse struct {
+	P1, U1, U2, Greatest, U3, U4, P4 EclipseContact
+
+	// Whether the moon is above the horizon during a part of the penumbral, partial and total phases
+	PenumbralVisible bool
+	PartialVisible   bool
+	TotalVisible     bool
+}
+
+// calculates the visibility of the lunar eclipse from the observer
+func GetLocalLunarEclipse(eclipse LunarEclipse, obs Observer) LocalLunarEclipse {
+	up := func(t time.Time) bool {
+		return GetMoonPosition(t, obs.Latitude, obs.Longitude).Altitude > 0
+	}
+	contact := func(t time.Time) EclipseContact {
+		if t.IsZero() {
+			return EclipseContact{}
+		}
+		return EclipseContact{t, GetMoonPosition(t, obs.Latitude, obs.Longitude).Altitude}
+	}
+	visible := func(start time.Time, end time.Time) bool {
+		return !start.IsZero() && len(findIntervals(start, end, time.Minute, up)) > 0
+	}
+
+	return LocalLunarEclipse{
+		P1:               contact(eclipse.P1),
+		U1:               contact(eclipse.U1),
+		U2:               contact(eclipse.U2),
+		Greatest:         contact(eclipse.Greatest),
+		U3:               contact(eclipse.U3),
+		U4:               contact(eclipse.U4),
+		P4:               contact(eclipse.P4),
+		PenumbralVisible: visible(eclipse.P1, eclipse.P4),
+		PartialVisible:   visible(eclipse.U1, eclipse.U4),
+		TotalVisible:     visible(eclipse.U2, eclipse.U3),
+	}
+}
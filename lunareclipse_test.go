@@ -0,0 +1,90 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetLunarEclipses(t *testing.T) {
+	// NASA five millennium canon of lunar eclipses
+	want := []struct {
+		kind              EclipseKind
+		greatest          time.Time
+		penumbral, umbral float64
+	}{
+		{PenumbralEclipse, time.Date(2023, 5, 5, 17, 22, 54, 0, time.UTC), 0.9655, -0.0464},
+		{PartialEclipse, time.Date(2023, 10, 28, 20, 14, 5, 0, time.UTC), 1.1181, 0.1220},
+		{PenumbralEclipse, time.Date(2024, 3, 25, 7, 12, 51, 0, time.UTC), 0.9577, -0.1304},
+		{PartialEclipse, time.Date(2024, 9, 18, 2, 44, 18, 0, time.UTC), 1.0366, 0.0849},
+		{TotalEclipse, time.Date(2025, 3, 14, 6, 58, 43, 0, time.UTC), 2.2595, 1.1784},
+		{TotalEclipse, time.Date(2025, 9, 7, 18, 11, 48, 0, time.UTC), 2.3440, 1.3619},
+	}
+
+	got := GetLunarEclipses(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(got) != len(want) {
+		t.Fatalf("GetLunarEclipses() = %d eclipses, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		w := want[i]
+		if d := e.Greatest.Sub(w.greatest); e.Kind != w.kind || d > time.Minute || d < -time.Minute ||
+			math.Abs(e.PenumbralMagnitude-w.penumbral) > 0.01 || math.Abs(e.UmbralMagnitude-w.umbral) > 0.01 {
+			t.Errorf("GetLunarEclipses()[%d] = %v %v %v %v, want %v %v %v %v", i,
+				e.Kind, e.Greatest, e.PenumbralMagnitude, e.UmbralMagnitude, w.kind, w.greatest, w.penumbral, w.umbral)
+		}
+
+		phases := map[EclipseKind]int{PenumbralEclipse: 2, PartialEclipse: 4, TotalEclipse: 6}
+		count := 0
+		for _, c := range []time.Time{e.P1, e.U1, e.U2, e.U3, e.U4, e.P4} {
+			if !c.IsZero() {
+				count++
+			}
+		}
+		if count != phases[e.Kind] {
+			t.Errorf("GetLunarEclipses()[%d] %v eclipse with %d contacts", i, e.Kind, count)
+		}
+	}
+}
+
+func TestGetLunarEclipseContacts(t *testing.T) {
+	eclipses := GetLunarEclipses(time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 11, 30, 0, 0, 0, 0, time.UTC))
+	if len(eclipses) != 1 {
+		t.Fatalf("GetLunarEclipses() = %v, want the eclipse of 2022-11-08", eclipses)
+	}
+	e := eclipses[0]
+
+	day := time.Date(2022, 11, 8, 0, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		name      string
+		got, want time.Time
+	}{
+		{"P1", e.P1, day.Add(8*time.Hour + 2*time.Minute + 17*time.Second)},
+		{"U1", e.U1, day.Add(9*time.Hour + 9*time.Minute + 12*time.Second)},
+		{"U2", e.U2, day.Add(10*time.Hour + 16*time.Minute + 39*time.Second)},
+		{"U3", e.U3, day.Add(11*time.Hour + 41*time.Minute + 35*time.Second)},
+		{"U4", e.U4, day.Add(12*time.Hour + 49*time.Minute + 1*time.Second)},
+		{"P4", e.P4, day.Add(13*time.Hour + 56*time.Minute + 9*time.Second)},
+	} {
+		if d := c.got.Sub(c.want); d > time.Minute || d < -time.Minute {
+			t.Errorf("GetLunarEclipses() %s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	honolulu := GetLocalLunarEclipse(e, Observer{21.31, -157.86, 0, time.UTC, nil})
+	if !honolulu.PenumbralVisible || !honolulu.PartialVisible || !honolulu.TotalVisible || honolulu.Greatest.Altitude < 30*rad {
+		t.Errorf("GetLocalLunarEclipse() Honolulu = %+v", honolulu)
+	}
+	if want := GetMoonPosition(e.U2, 21.31, -157.86).Altitude; honolulu.U2.Altitude != want || !honolulu.U2.Time.Equal(e.U2) {
+		t.Errorf("GetLocalLunarEclipse() Honolulu U2 = %+v, want the altitude %v", honolulu.U2, want)
+	}
+
+	// the moon sets in New York after the total phase, and is below the horizon in Paris
+	newYork := GetLocalLunarEclipse(e, Observer{40.71, -74.01, 0, time.UTC, nil})
+	if !newYork.TotalVisible || newYork.U3.Altitude < 0 || newYork.U4.Altitude > 0 {
+		t.Errorf("GetLocalLunarEclipse() New York = %+v", newYork)
+	}
+	paris := GetLocalLunarEclipse(e, Observer{48.85, 2.35, 0, time.UTC, nil})
+	if paris.PenumbralVisible || paris.PartialVisible || paris.TotalVisible {
+		t.Errorf("GetLocalLunarEclipse() Paris = %+v", paris)
+	}
+}
@@ -0,0 +1,202 @@
+package suncalc
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// planets calculations, based on the approximate Keplerian elements of E. M. Standish (JPL), valid from 1800 to 2050,
+// and on the magnitude formulas of chapter 41 of "Astronomical Algorithms" 2nd edition by Jean Meeus (Willmann-Bell, Richmond) 1998.
+//
+// Unlike the sun and the moon, the planets do not use the Astronomy Answers formulas: their mean orbits have no rates
+// for the inclination, node and perihelion, nor for the orbit of the Earth, and drift by several minutes of arc over
+// a century. The JPL elements keep the geocentric positions within about a minute of arc of VSOP87 over their range.
+
+type Planet int
+
+const (
+	Mercury Planet = iota
+	Venus
+	Mars
+	Jupiter
+	Saturn
+	Uranus
+	Neptune
+)
+
+var planetNames = []string{"Mercury", "Venus", "Mars", "Jupiter", "Saturn", "Uranus", "Neptune"}
+
+func (p Planet) String() string {
+	if p < 0 || int(p) >= len(planetNames) {
+		return fmt.Sprintf("Planet(%d)", int(p))
+	}
+	return planetNames[p]
+}
+
+// Keplerian elements at J2000 and their rates per century: semi-major axis (AU), eccentricity, inclination,
+// mean longitude, longitude of the perihelion and longitude of the ascending node (degrees)
+type orbitalElements struct {
+	a, e, i, L, peri, node                         float64
+	aRate, eRate, iRate, LRate, periRate, nodeRate float64
+}
+
+var (
+	earthElements  = orbitalElements{1.00000261, 0.01671123, -0.00001531, 100.46457166, 102.93768193, 0, 0.00000562, -0.00004392, -0.01294668, 35999.37244981, 0.32327364, 0}
+	planetElements = []orbitalElements{
+		{0.38709927, 0.20563593, 7.00497902, 252.25032350, 77.45779628, 48.33076593, 0.00000037, 0.00001906, -0.00594749, 149472.67411175, 0.16047689, -0.12534081},
+		{0.72333566, 0.00677672, 3.39467605, 181.97909950, 131.60246718, 76.67984255, 0.00000390, -0.00004107, -0.00078890, 58517.81538729, 0.00268329, -0.27769418},
+		{1.52371034, 0.09339410, 1.84969142, -4.55343205, -23.94362959, 49.55953891, 0.00001847, 0.00007882, -0.00813131, 19140.30268499, 0.44441088, -0.29257343},
+		{5.20288700, 0.04838624, 1.30439695, 34.39644051, 14.72847983, 100.47390909, -0.00011607, -0.00013253, -0.00183714, 3034.74612775, 0.21252668, 0.20469106},
+		{9.53667594, 0.05386179, 2.48599187, 49.95424423, 92.59887831, 113.66242448, -0.00125060, -0.00050991, 0.00193609, 1222.49362201, -0.41897216, -0.28867794},
+		{19.18916464, 0.04725744, 0.77263783, 313.23810451, 170.95427630, 74.01692503, -0.00196176, -0.00004397, -0.00242939, 428.48202785, 0.40805281, 0.04240589},
+		{30.06992276, 0.00859048, 1.77004347, -55.12002969, 44.96476227, 131.78422574, 0.00026291, 0.00005105, 0.00035372, 218.45945325, -0.32241464, -0.00508664},
+	}
+)
+
+// returns the heliocentric ecliptic rectangular coordinates (J2000) in AU, T being in Julian centuries since J2000
+func (el orbitalElements) position(T float64) [3]float64 {
+	a := el.a + el.aRate*T
+	e := el.e + el.eRate*T
+	i := rad * (el.i + el.iRate*T)
+	L := el.L + el.LRate*T
+	peri := el.peri + el.periRate*T
+	node := rad * (el.node + el.nodeRate*T)
+	w := rad*peri - node
+	M := math.Remainder(rad*(L-peri), 2*math.Pi)
+
+	// Kepler's equation, by Newton's method
+	E := M + e*math.Sin(M)
+	for k := 0; k < 10; k++ {
+		dE := (E - e*math.Sin(E) - M) / (1 - e*math.Cos(E))
+		E -= dE
+		if math.Abs(dE) < 1e-12 {
+			break
+		}
+	}
+
+	x := a * (math.Cos(E) - e)
+	y := a * math.Sqrt(1-e*e) * math.Sin(E)
+
+	sinW, cosW := math.Sincos(w)
+	sinN, cosN := math.Sincos(node)
+	sinI, cosI := math.Sincos(i)
+	return [3]float64{
+		(cosW*cosN-sinW*sinN*cosI)*x + (-sinW*cosN-cosW*sinN*cosI)*y,
+		(cosW*sinN+sinW*cosN*cosI)*x + (-sinW*sinN+cosW*cosN*cosI)*y,
+		sinW*sinI*x + cosW*sinI*y,
+	}
+}
+
+// light time for one AU, in days
+const lightTimeAU = 0.0057755183
+
+type planetCoords struct {
+	lon, lat float64 // geocentric ecliptic coordinates of the date, in radians
+	r        float64 // distance to the sun in AU
+	delta    float64 // distance to the Earth in AU
+	R        float64 // distance from the Earth to the sun in AU
+}
+
+func planetCoordinates(p Planet, d float64) planetCoords {
+	T := d / 36525
+	earth := earthElements.position(T)
+
+	// the planet is seen where it was when the light left it
+	var planet, geo [3]float64
+	delta := 0.0
+	for k := 0; k < 2; k++ {
+		planet = planetElements[p].position(T - delta*lightTimeAU/36525)
+		geo = [3]float64{planet[0] - earth[0], planet[1] - earth[1], planet[2] - earth[2]}
+		delta = math.Sqrt(geo[0]*geo[0] + geo[1]*geo[1] + geo[2]*geo[2])
+	}
+
+	lon, lat := precessEcliptic(math.Atan2(geo[1], geo[0]), math.Asin(geo[2]/delta), T)
+	return planetCoords{
+		lon:   lon,
+		lat:   lat,
+		r:     math.Sqrt(planet[0]*planet[0] + planet[1]*planet[1] + planet[2]*planet[2]),
+		delta: delta,
+		R:     math.Sqrt(earth[0]*earth[0] + earth[1]*earth[1] + earth[2]*earth[2]),
+	}
+}
+
+type PlanetPosition struct {
+	Azimuth  float64
+	Altitude float64
+
+	RightAscension float64
+	Declination    float64
+
+	// Distance from the Earth, in AU
+	Distance float64
+
+	// Visual magnitude
+	Magnitude float64
+
+	// Phase angle (sun-planet-Earth), in radians, and illuminated fraction of the disk
+	PhaseAngle float64
+	Fraction   float64
+}
+
+// calculates the position of a planet for a given date and latitude/longitude,
+// the altitude being corrected for the refraction like GetMoonPosition
+func GetPlanetPosition(date time.Time, lat float64, lng float64, p Planet) PlanetPosition {
+	lw := rad * -lng
+	phi := rad * lat
+	d := toDays(date)
+
+	c := planetCoordinates(p, d)
+	q := trueEquinox(d)
+	ra, dec := q.equatorial(c.lon, c.lat)
+	H := q.siderealTime(lw) - ra
+	h := altitude(H, phi, dec)
+
+	cosI := (c.r*c.r + c.delta*c.delta - c.R*c.R) / (2 * c.r * c.delta)
+	phase := math.Acos(math.Max(-1, math.Min(1, cosI)))
+
+	return PlanetPosition{
+		Azimuth:        azimuth(H, phi, dec),
+		Altitude:       h + astroRefraction(h),
+		RightAscension: ra,
+		Declination:    dec,
+		Distance:       c.delta,
+		Magnitude:      planetMagnitude(p, c, phase, d),
+		PhaseAngle:     phase,
+		Fraction:       (1 + math.Cos(phase)) / 2,
+	}
+}
+
+// returns the visual magnitude of the planet (formulas of the Astronomical Almanac 1984, Meeus 41)
+func planetMagnitude(p Planet, c planetCoords, phase float64, d float64) float64 {
+	i := phase / rad
+	m := 5 * math.Log10(c.r*c.delta)
+	switch p {
+	case Mercury:
+		return m - 0.42 + 0.0380*i - 0.000273*i*i + 0.000002*i*i*i
+	case Venus:
+		return m - 4.40 + 0.0009*i + 0.000239*i*i - 0.00000065*i*i*i
+	case Mars:
+		return m - 1.52 + 0.016*i
+	case Jupiter:
+		return m - 9.40 + 0.005*i
+	case Saturn:
+		// the rings, seen from the Earth at the latitude B above their plane (Meeus 45)
+		T := d / 36525
+		ringInclination := rad * (28.075216 - 0.012998*T)
+		ringNode := rad * (169.508470 + 1.394681*T)
+		sinB := math.Abs(math.Sin(ringInclination)*math.Cos(c.lat)*math.Sin(c.lon-ringNode) - math.Cos(ringInclination)*math.Sin(c.lat))
+		return m - 8.88 - 2.60*sinB + 1.25*sinB*sinB
+	case Uranus:
+		return m - 7.19
+	}
+	return m - 6.87
+}
+
+type PlanetTimes BodyTimes
+
+// calculates the rise, transit and set times of a planet for the observer's local day,
+// over the observer's horizon profile if any. Zero when they do not occur during the day
+func GetPlanetTimesWithObserver(date time.Time, obs Observer, p Planet) PlanetTimes {
+	return PlanetTimes(GetBodyTimes(date, obs, p))
+}
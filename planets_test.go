@@ -0,0 +1,124 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetPlanetPosition(t *testing.T) {
+	// example 33.a of "Astronomical Algorithms": Venus on 1992 December 20 at 0h TD,
+	// and example 41.a for its illuminated fraction
+	date := time.Date(1992, 12, 19, 23, 59, 0, 0, time.UTC)
+	pos := GetPlanetPosition(date, 0, 0, Venus)
+
+	ra := math.Mod(pos.RightAscension+2*math.Pi, 2*math.Pi)
+	arcmin := rad / 60
+	if math.Abs(ra-316.172728*rad) > 0.5*arcmin {
+		t.Errorf("RightAscension = %v, want 316.172728", ra/rad)
+	}
+	if math.Abs(pos.Declination-(-18.888010*rad)) > 0.5*arcmin {
+		t.Errorf("Declination = %v, want -18.888010", pos.Declination/rad)
+	}
+	if math.Abs(pos.Distance-0.910947) > 0.0001 {
+		t.Errorf("Distance = %v, want 0.910947", pos.Distance)
+	}
+	if math.Abs(pos.Fraction-0.647) > 0.005 {
+		t.Errorf("Fraction = %v, want 0.647", pos.Fraction)
+	}
+	if math.Abs(pos.Magnitude-(-4.2)) > 0.1 {
+		t.Errorf("Magnitude = %v, want -4.2", pos.Magnitude)
+	}
+}
+
+func TestPlanetHeliocentric(t *testing.T) {
+	// example 32.a of "Astronomical Algorithms": Venus on 1992 December 20 at 0h TD, from the full VSOP87 theory,
+	// referred to the ecliptic and equinox of the date
+	T := toDays(time.Date(1992, 12, 19, 23, 59, 0, 0, time.UTC)) / 36525
+	p := planetElements[Venus].position(T)
+	r := math.Sqrt(p[0]*p[0] + p[1]*p[1] + p[2]*p[2])
+	l, b := precessEcliptic(math.Atan2(p[1], p[0]), math.Asin(p[2]/r), T)
+
+	arcmin := rad / 60
+	if l = math.Mod(l+2*math.Pi, 2*math.Pi); math.Abs(l-26.11428*rad) > arcmin {
+		t.Errorf("longitude = %v, want 26.11428", l/rad)
+	}
+	if math.Abs(b-(-2.62070*rad)) > arcmin {
+		t.Errorf("latitude = %v, want -2.62070", b/rad)
+	}
+	if math.Abs(r-0.724603) > 0.0001 {
+		t.Errorf("radius vector = %v, want 0.724603", r)
+	}
+}
+
+func TestPlanetMagnitudes(t *testing.T) {
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		planet   Planet
+		min, max float64
+	}{
+		{Mercury, -2.5, 5.5},
+		{Venus, -4.9, -3.8},
+		{Mars, -3, 2},
+		{Jupiter, -3, -1.6},
+		{Saturn, -0.6, 1.5},
+		{Uranus, 5.3, 6},
+		{Neptune, 7.7, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.planet.String(), func(t *testing.T) {
+			if got := GetPlanetPosition(date, 0, 0, tt.planet).Magnitude; got < tt.min || got > tt.max {
+				t.Errorf("Magnitude = %v, want between %v and %v", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestGetPlanetTimesWithObserver(t *testing.T) {
+	// example 15.a of "Astronomical Algorithms": Venus at Boston on 1988 March 20
+	obs := Observer{42.3333, -71.0833, 0, time.UTC, nil}
+	times := GetPlanetTimesWithObserver(time.Date(1988, 3, 20, 0, 0, 0, 0, time.UTC), obs, Venus)
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"Rise", times.Rise, time.Date(1988, 3, 20, 12, 25, 0, 0, time.UTC)},
+		{"Transit", times.Transit, time.Date(1988, 3, 20, 19, 41, 0, 0, time.UTC)},
+		{"Set", times.Set, time.Date(1988, 3, 20, 2, 55, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := tt.got.Sub(tt.want); diff < -2*time.Minute || diff > 2*time.Minute {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+	if times.AlwaysUp || times.AlwaysDown {
+		t.Errorf("AlwaysUp = %v, AlwaysDown = %v, want false", times.AlwaysUp, times.AlwaysDown)
+	}
+
+	// Saturn stays below the horizon at the North Pole while its declination is negative
+	pole := Observer{89.9, 0, 0, time.UTC, nil}
+	if got := GetPlanetTimesWithObserver(time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC), pole, Saturn); !got.AlwaysDown {
+		t.Errorf("GetPlanetTimesWithObserver() at the pole = %+v, want AlwaysDown", got)
+	}
+}
+
+func TestPlanetString(t *testing.T) {
+	tests := []struct {
+		planet Planet
+		want   string
+	}{
+		{Mercury, "Mercury"},
+		{Neptune, "Neptune"},
+		{Planet(7), "Planet(7)"},
+		{Planet(-1), "Planet(-1)"},
+	}
+	for _, tt := range tests {
+		if got := tt.planet.String(); got != tt.want {
+			t.Errorf("Planet(%d).String() = %v, want %v", int(tt.planet), got, tt.want)
+		}
+	}
+}
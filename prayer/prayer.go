@@ -0,0 +1,150 @@
+// Package prayer calculates the Islamic prayer times from the position of the sun.
+//
+// Fajr and Isha happen when the sun reaches a depression angle below the horizon that depends
+// on the calculation method, Asr when the shadow of an object reaches a multiple of its length
+// plus its shadow at noon, Dhuhr after the solar noon and Maghrib at sunset.
+package prayer
+
+import (
+	"math"
+	"time"
+
+	"github.com/sixdouglas/suncalc"
+)
+
+const rad = math.Pi / 180
+
+// Method holds the angles of a calculation method
+type Method struct {
+	Name string
+
+	// Depression of the sun below the horizon at Fajr, in degrees
+	FajrAngle float64
+
+	// Depression of the sun below the horizon at Isha, in degrees
+	IshaAngle float64
+
+	// Delay of Isha after Maghrib, used instead of IshaAngle when set
+	IshaInterval time.Duration
+}
+
+// standard calculation methods
+var (
+	MWL       = Method{"Muslim World League", 18, 17, 0}
+	ISNA      = Method{"Islamic Society of North America", 15, 15, 0}
+	Egyptian  = Method{"Egyptian General Authority of Survey", 19.5, 17.5, 0}
+	Karachi   = Method{"University of Islamic Sciences, Karachi", 18, 18, 0}
+	UmmAlQura = Method{"Umm al-Qura University, Makkah", 18.5, 0, 90 * time.Minute}
+)
+
+// AsrMethod is the length of the shadow of an object at Asr, relative to the object, in
+// addition to its shadow at noon
+type AsrMethod float64
+
+const (
+	Shafi  AsrMethod = 1 // Shafi, Maliki and Hanbali schools
+	Hanafi AsrMethod = 2 // Hanafi school
+)
+
+// HighLatitudeRule sets Fajr and Isha when the twilight never gets dark enough, or lasts too
+// long, in high latitudes: they are limited to a portion of the night from sunset to sunrise
+type HighLatitudeRule int
+
+const (
+	NoAdjustment      HighLatitudeRule = iota // Fajr and Isha are zero when the sun does not reach their angle
+	MiddleOfTheNight                          // at most half of the night
+	SeventhOfTheNight                         // at most a seventh of the night
+	TwilightAngle                             // at most the angle divided by 60 of the night
+)
+
+type Config struct {
+	Method           Method
+	Asr              AsrMethod
+	HighLatitudeRule HighLatitudeRule
+
+	// Delay of Dhuhr after the solar noon, a few minutes are commonly added
+	DhuhrDelay time.Duration
+}
+
+type Times struct {
+	Fajr    time.Time
+	Sunrise time.Time
+	Dhuhr   time.Time
+	Asr     time.Time
+	Maghrib time.Time
+	Isha    time.Time
+}
+
+// calculates the prayer times of the observer's local day of the given date
+func GetTimes(date time.Time, obs suncalc.Observer, config Config) Times {
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, obs.Location)
+	times := suncalc.GetTimesWithObserver(noon, obs)
+
+	// the angles of Fajr, Asr and Isha are measured from the mathematical horizon
+	geometric := obs
+	geometric.Height = 0
+
+	result := Times{
+		Sunrise: times[suncalc.Sunrise].Value,
+		Dhuhr:   times[suncalc.SolarNoon].Value.Add(config.DhuhrDelay),
+		Maghrib: times[suncalc.Sunset].Value,
+	}
+	result.Fajr, _ = suncalc.GetAltitudeTimes(noon, geometric, -config.Method.FajrAngle)
+
+	// shadow ratio at noon is cot(noon altitude), the altitude of the sun at Asr is acot(ratio + factor)
+	asr := config.Asr
+	if asr == 0 {
+		asr = Shafi
+	}
+	noonAltitude := suncalc.GetPosition(times[suncalc.SolarNoon].Value, obs.Latitude, obs.Longitude).Altitude
+	asrAltitude := math.Atan(1 / (float64(asr) + 1/math.Tan(noonAltitude)))
+	_, result.Asr = suncalc.GetAltitudeTimes(noon, geometric, asrAltitude/rad)
+
+	if config.Method.IshaInterval > 0 {
+		if !result.Maghrib.IsZero() {
+			result.Isha = result.Maghrib.Add(config.Method.IshaInterval)
+		}
+	} else {
+		_, result.Isha = suncalc.GetAltitudeTimes(noon, geometric, -config.Method.IshaAngle)
+	}
+
+	adjustHighLatitude(&result, noon, obs, config)
+	return result
+}
+
+// limits Fajr and Isha to a portion of the night according to the high latitude rule
+func adjustHighLatitude(result *Times, noon time.Time, obs suncalc.Observer, config Config) {
+	if config.HighLatitudeRule == NoAdjustment || result.Sunrise.IsZero() || result.Maghrib.IsZero() {
+		return
+	}
+
+	_, previousSunset := suncalc.GetAltitudeTimes(noon.AddDate(0, 0, -1), obs, -0.833)
+	nextSunrise, _ := suncalc.GetAltitudeTimes(noon.AddDate(0, 0, 1), obs, -0.833)
+
+	portion := func(angle float64) float64 {
+		switch config.HighLatitudeRule {
+		case MiddleOfTheNight:
+			return 1. / 2
+		case SeventhOfTheNight:
+			return 1. / 7
+		default:
+			return angle / 60
+		}
+	}
+
+	if !previousSunset.IsZero() {
+		night := result.Sunrise.Sub(previousSunset)
+		earliest := result.Sunrise.Add(-time.Duration(portion(config.Method.FajrAngle) * float64(night)))
+		if result.Fajr.IsZero() || result.Fajr.Before(earliest) {
+			result.Fajr = earliest
+		}
+	}
+
+	if !nextSunrise.IsZero() && config.Method.IshaInterval == 0 {
+		night := nextSunrise.Sub(result.Maghrib)
+		latest := result.Maghrib.Add(time.Duration(portion(config.Method.IshaAngle) * float64(night)))
+		if result.Isha.IsZero() || result.Isha.After(latest) {
+			result.Isha = latest
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package prayer
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sixdouglas/suncalc"
+)
+
+func altitude(t time.Time, obs suncalc.Observer) float64 {
+	return suncalc.GetPosition(t, obs.Latitude, obs.Longitude).Altitude / rad
+}
+
+func TestGetTimesMethods(t *testing.T) {
+	date := time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC)
+	cairo := suncalc.Observer{Latitude: 30.0444, Longitude: 31.2357, Location: time.FixedZone("EET", 2*60*60)}
+
+	tests := []struct {
+		name   string
+		method Method
+	}{
+		{"MWL", MWL},
+		{"ISNA", ISNA},
+		{"Egyptian", Egyptian},
+		{"Karachi", Karachi},
+		{"Umm al-Qura", UmmAlQura},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetTimes(date, cairo, Config{Method: tt.method})
+
+			order := []time.Time{got.Fajr, got.Sunrise, got.Dhuhr, got.Asr, got.Maghrib, got.Isha}
+			for i := 1; i < len(order); i++ {
+				if !order[i].After(order[i-1]) {
+					t.Fatalf("GetTimes() = %+v, times are not in order", got)
+				}
+			}
+			if got.Fajr.Day() != 17 || got.Isha.Day() != 17 {
+				t.Errorf("GetTimes() = %+v, want the times of the 17th", got)
+			}
+
+			// GetPosition and GetTimesWithObserver agree within a few tenths of a degree
+			if a := altitude(got.Fajr, cairo); math.Abs(a+tt.method.FajrAngle) > 0.2 {
+				t.Errorf("Fajr at altitude %v, want %v", a, -tt.method.FajrAngle)
+			}
+			if tt.method.IshaInterval > 0 {
+				if d := got.Isha.Sub(got.Maghrib); d != tt.method.IshaInterval {
+					t.Errorf("Isha %v after Maghrib, want %v", d, tt.method.IshaInterval)
+				}
+			} else if a := altitude(got.Isha, cairo); math.Abs(a+tt.method.IshaAngle) > 0.2 {
+				t.Errorf("Isha at altitude %v, want %v", a, -tt.method.IshaAngle)
+			}
+		})
+	}
+
+	// the larger the angle, the earlier Fajr
+	if mwl, egypt, isna := GetTimes(date, cairo, Config{Method: MWL}), GetTimes(date, cairo, Config{Method: Egyptian}), GetTimes(date, cairo, Config{Method: ISNA}); !egypt.Fajr.Before(mwl.Fajr) || !mwl.Fajr.Before(isna.Fajr) {
+		t.Errorf("GetTimes() Fajr Egyptian %v, MWL %v, ISNA %v", egypt.Fajr, mwl.Fajr, isna.Fajr)
+	}
+}
+
+func TestGetTimesAsr(t *testing.T) {
+	date := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	obs := suncalc.Observer{Latitude: 24.8607, Longitude: 67.0011, Location: time.FixedZone("PKT", 5*60*60)}
+
+	tests := []struct {
+		name string
+		asr  AsrMethod
+	}{
+		{"Shafi", Shafi},
+		{"Hanafi", Hanafi},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetTimes(date, obs, Config{Method: Karachi, Asr: tt.asr, DhuhrDelay: time.Minute})
+
+			noon := suncalc.GetShadow(got.Dhuhr.Add(-time.Minute), obs.Latitude, obs.Longitude, 1).Length
+			asr := suncalc.GetShadow(got.Asr, obs.Latitude, obs.Longitude, 1).Length
+			// the shadow grows fast in the afternoon, and Asr keeps the declination of the sun at noon
+			if math.Abs(asr-noon-float64(tt.asr)) > 0.002 {
+				t.Errorf("Asr shadow = %v, want %v", asr, noon+float64(tt.asr))
+			}
+		})
+	}
+
+	shafi := GetTimes(date, obs, Config{Method: Karachi})
+	hanafi := GetTimes(date, obs, Config{Method: Karachi, Asr: Hanafi})
+	if d := hanafi.Asr.Sub(shafi.Asr); d < 30*time.Minute || d > 90*time.Minute {
+		t.Errorf("Hanafi Asr %v after Shafi, want about an hour", d)
+	}
+	if noon := suncalc.GetTimes(date.Add(12*time.Hour), obs.Latitude, obs.Longitude)[suncalc.SolarNoon].Value; !shafi.Dhuhr.Equal(noon) {
+		t.Errorf("Dhuhr = %v, want %v", shafi.Dhuhr, noon)
+	}
+}
+
+func TestGetTimesHighLatitude(t *testing.T) {
+	// the sun never goes 18 degrees below the horizon in June in Oslo
+	date := time.Date(2020, 6, 21, 0, 0, 0, 0, time.UTC)
+	oslo := suncalc.Observer{Latitude: 59.9139, Longitude: 10.7522, Location: time.FixedZone("CEST", 2*60*60)}
+
+	none := GetTimes(date, oslo, Config{Method: MWL})
+	if !none.Fajr.IsZero() || !none.Isha.IsZero() {
+		t.Errorf("GetTimes() = %+v, want no Fajr and Isha", none)
+	}
+
+	tests := []struct {
+		name    string
+		rule    HighLatitudeRule
+		portion float64
+	}{
+		{"middle of the night", MiddleOfTheNight, 1. / 2},
+		{"seventh of the night", SeventhOfTheNight, 1. / 7},
+		{"twilight angle", TwilightAngle, 18. / 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetTimes(date, oslo, Config{Method: MWL, HighLatitudeRule: tt.rule})
+			_, sunset := suncalc.GetAltitudeTimes(date.Add(-12*time.Hour), oslo, -0.833)
+			night := got.Sunrise.Sub(sunset)
+			if want := got.Sunrise.Add(-time.Duration(tt.portion * float64(night))); !got.Fajr.Equal(want) {
+				t.Errorf("Fajr = %v, want %v", got.Fajr, want)
+			}
+			if got.Isha.IsZero() || !got.Isha.After(got.Maghrib) {
+				t.Errorf("Isha = %v, want after %v", got.Isha, got.Maghrib)
+			}
+		})
+	}
+
+	// the middle of the night rule does not change the times in low latitudes
+	cairo := suncalc.Observer{Latitude: 30.0444, Longitude: 31.2357, Location: time.UTC}
+	if a, b := GetTimes(date, cairo, Config{Method: MWL}), GetTimes(date, cairo, Config{Method: MWL, HighLatitudeRule: MiddleOfTheNight}); a != b {
+		t.Errorf("GetTimes() = %+v, want %+v", b, a)
+	}
+}
+
+func TestGetTimesTimetables(t *testing.T) {
+	// timetables of the PrayTimes.org calculator for each method, to the minute
+	tests := []struct {
+		name   string
+		date   time.Time
+		obs    suncalc.Observer
+		method Method
+		want   [6]string // Fajr, Sunrise, Dhuhr, Asr, Maghrib, Isha
+	}{
+		{"MWL, London", time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC), suncalc.Observer{Latitude: 51.5074, Longitude: -0.1278, Location: time.UTC},
+			MWL, [6]string{"05:59", "07:59", "12:10", "14:02", "16:21", "18:15"}},
+		{"ISNA, New York", time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), suncalc.Observer{Latitude: 40.7128, Longitude: -74.0060, Location: time.FixedZone("EST", -5*60*60)},
+			ISNA, [6]string{"05:15", "06:30", "12:08", "15:17", "17:48", "19:03"}},
+		{"Karachi, Karachi", time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC), suncalc.Observer{Latitude: 24.8607, Longitude: 67.0011, Location: time.FixedZone("PKT", 5*60*60)},
+			Karachi, [6]string{"05:22", "06:39", "12:16", "15:28", "17:52", "19:09"}},
+		{"Umm al-Qura, Makkah", time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), suncalc.Observer{Latitude: 21.4225, Longitude: 39.8262, Location: time.FixedZone("AST", 3*60*60)},
+			UmmAlQura, [6]string{"04:29", "05:50", "12:18", "15:39", "18:46", "20:16"}},
+	}
+	names := []string{"Fajr", "Sunrise", "Dhuhr", "Asr", "Maghrib", "Isha"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetTimes(tt.date, tt.obs, Config{Method: tt.method})
+			for i, value := range []time.Time{got.Fajr, got.Sunrise, got.Dhuhr, got.Asr, got.Maghrib, got.Isha} {
+				want, err := time.ParseInLocation("2006-01-02 15:04", tt.date.Format("2006-01-02 ")+tt.want[i], tt.obs.Location)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := value.Sub(want); diff < -time.Minute || diff > time.Minute {
+					t.Errorf("%s = %v, want %v", names[i], value.Format("15:04:05"), tt.want[i])
+				}
+			}
+		})
+	}
+}
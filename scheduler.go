@@ -0,0 +1,169 @@
+package suncalc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock gives the current time and waits, so that a Scheduler can be driven by a fake clock in tests
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Trigger is a sun time, shifted by an offset (e.g. Sunset and 30 minutes)
+type Trigger struct {
+	Name   DayTimeName
+	Offset time.Duration
+}
+
+type SolarEvent struct {
+	Trigger Trigger
+	Time    time.Time
+}
+
+// number of days searched for the next event, long enough to get out of a polar day or night
+const schedulerHorizon = 367
+
+// Scheduler fires the triggers every day at the sun times of the observer
+type Scheduler struct {
+	Observer Observer
+	Triggers []Trigger
+
+	// Clock used to wait for the events, the system clock if nil
+	Clock Clock
+
+	mu  sync.Mutex
+	err error // error that closed the channel of Events
+}
+
+// returns the first event strictly after the given time, false if none occurs within a year
+func (s *Scheduler) Next(after time.Time) (SolarEvent, bool) {
+	events := s.next(after)
+	if len(events) == 0 {
+		return SolarEvent{}, false
+	}
+	return events[0], true
+}
+
+// returns the events happening at the earliest instant strictly after the given time, in the order of the triggers
+func (s *Scheduler) next(after time.Time) []SolarEvent {
+	var maxOffset time.Duration
+	for _, trigger := range s.Triggers {
+		if trigger.Offset > maxOffset {
+			maxOffset = trigger.Offset
+		} else if -trigger.Offset > maxOffset {
+			maxOffset = -trigger.Offset
+		}
+	}
+
+	// the sun times are calculated per local day, at noon, so that DST transitions are taken into account;
+	// days around the searched time are included as the offsets may move their events to another day
+	local := after.In(s.Observer.Location)
+	skip := int(maxOffset/(24*time.Hour)) + 1
+	var result []SolarEvent
+	for i := -skip; i <= schedulerHorizon; i++ {
+		noon := time.Date(local.Year(), local.Month(), local.Day()+i, 12, 0, 0, 0, s.Observer.Location)
+		if len(result) > 0 && noon.Add(-36*time.Hour-maxOffset).After(result[0].Time) {
+			break
+		}
+
+		times := GetTimesWithObserver(noon, s.Observer)
+		for _, trigger := range s.Triggers {
+			value := times[trigger.Name].Value
+			if value.IsZero() { // polar day or night
+				continue
+			}
+			t := value.Add(trigger.Offset)
+			switch {
+			case !t.After(after):
+			case len(result) == 0 || t.Before(result[0].Time):
+				result = []SolarEvent{{trigger, t}}
+			case t.Equal(result[0].Time):
+				result = append(result, SolarEvent{trigger, t})
+			}
+		}
+	}
+
+	order := make(map[Trigger]int, len(s.Triggers))
+	for i, trigger := range s.Triggers {
+		if _, ok := order[trigger]; !ok {
+			order[trigger] = i
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return order[result[i].Trigger] < order[result[j].Trigger] })
+	return result
+}
+
+// calls the handler for every event, at its time, until the context is done; the sun times
+// are calculated again for every day. Returns the context error, or an error if no event
+// occurs anymore within a year
+func (s *Scheduler) Run(ctx context.Context, handler func(SolarEvent)) error {
+	clock := s.Clock
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	last := clock.Now()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		events := s.next(last)
+		if len(events) == 0 {
+			return fmt.Errorf("suncalc: no sun event within %d days after %v", schedulerHorizon, last)
+		}
+		last = events[0].Time
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(last.Sub(clock.Now())):
+		}
+		for _, event := range events {
+			handler(event)
+		}
+	}
+}
+
+// runs the scheduler in a goroutine, sending the events on the returned channel,
+// which is closed when the context is done or when no event occurs anymore within a year;
+// Err then tells which
+func (s *Scheduler) Events(ctx context.Context) <-chan SolarEvent {
+	events := make(chan SolarEvent)
+	go func() {
+		defer close(events)
+		err := s.Run(ctx, func(event SolarEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+	}()
+	return events
+}
+
+// returns the error of Run that closed the channel of the last call to Events: the context error,
+// or the error telling that no event occurs anymore. Nil while the channel is open
+func (s *Scheduler) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
@@ -0,0 +1,141 @@
+package suncalc
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock jumps to the end of every wait instead of sleeping
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	if d > 0 {
+		c.now = c.now.Add(d)
+	}
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestSchedulerNext(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+	obs := Observer{Latitude: 48.85, Longitude: 2.35, Location: paris}
+	s := Scheduler{Observer: obs, Triggers: []Trigger{{Sunrise, 0}, {Sunset, 30 * time.Minute}}}
+
+	// DST starts on 2021-03-28 in Paris: the sunrise is an hour later in local time
+	for _, day := range []int{27, 28} {
+		noon := time.Date(2021, 3, day, 12, 0, 0, 0, paris)
+		want := GetTimesWithObserver(noon, obs)
+
+		got, ok := s.Next(time.Date(2021, 3, day, 0, 0, 0, 0, paris))
+		if !ok || got.Trigger.Name != Sunrise || !got.Time.Equal(want[Sunrise].Value) {
+			t.Errorf("Next() = %v, want sunrise at %v", got, want[Sunrise].Value)
+		}
+
+		got, ok = s.Next(want[Sunrise].Value)
+		if wantSunset := want[Sunset].Value.Add(30 * time.Minute); !ok || !got.Time.Equal(wantSunset) {
+			t.Errorf("Next() = %v, want sunset + 30m at %v", got, wantSunset)
+		}
+	}
+
+	// an offset moving the event to the next day
+	late := Scheduler{Observer: obs, Triggers: []Trigger{{Sunset, 6 * time.Hour}}}
+	got, _ := late.Next(time.Date(2021, 6, 22, 1, 0, 0, 0, paris))
+	want := GetTimesWithObserver(time.Date(2021, 6, 21, 12, 0, 0, 0, paris), obs)[Sunset].Value.Add(6 * time.Hour)
+	if !got.Time.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got.Time, want)
+	}
+}
+
+func TestSchedulerNextPolar(t *testing.T) {
+	obs := Observer{Latitude: 78.2, Longitude: 15.6, Location: time.UTC}
+	s := Scheduler{Observer: obs, Triggers: []Trigger{{Sunset, 0}}}
+
+	// no sunset during the midnight sun, the first one is in late August
+	got, ok := s.Next(time.Date(2021, 6, 21, 0, 0, 0, 0, time.UTC))
+	if !ok || got.Time.Month() != time.August {
+		t.Errorf("Next() = %v, %v, want a sunset in August", got, ok)
+	}
+
+	// without any trigger, nothing ever happens
+	empty := Scheduler{Observer: obs, Clock: &fakeClock{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	if _, ok := empty.Next(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("Next() found an event without triggers")
+	}
+	if err := empty.Run(context.Background(), func(SolarEvent) {}); err == nil {
+		t.Errorf("Run() error = nil, want an error without triggers")
+	}
+}
+
+func TestSchedulerRun(t *testing.T) {
+	obs := Observer{Latitude: 51.5, Longitude: -0.1, Location: time.UTC}
+	clock := &fakeClock{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := Scheduler{Observer: obs, Triggers: []Trigger{{Sunrise, 0}, {Sunset, 0}}, Clock: clock}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var events []SolarEvent
+	err := s.Run(ctx, func(event SolarEvent) {
+		if !clock.Now().Equal(event.Time) {
+			t.Errorf("event %v fired at %v", event, clock.Now())
+		}
+		events = append(events, event)
+		if len(events) == 6 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+
+	if len(events) != 6 {
+		t.Fatalf("Run() fired %d events, want 6", len(events))
+	}
+	for i, event := range events {
+		day := time.Date(2021, 1, 1+i/2, 12, 0, 0, 0, time.UTC)
+		name := []DayTimeName{Sunrise, Sunset}[i%2]
+		if want := GetTimesWithObserver(day, obs)[name].Value; event.Trigger.Name != name || !event.Time.Equal(want) {
+			t.Errorf("event %d = %v, want %v at %v", i, event, name, want)
+		}
+	}
+}
+
+func TestSchedulerEvents(t *testing.T) {
+	obs := Observer{Latitude: 51.5, Longitude: -0.1, Location: time.UTC}
+	clock := &fakeClock{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := Scheduler{Observer: obs, Triggers: []Trigger{{Dawn, 0}, {SolarNoon, -time.Hour}}, Clock: clock}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := s.Events(ctx)
+	first, second := <-events, <-events
+	cancel()
+	for range events { // drained until closed
+	}
+
+	if first.Trigger.Name != Dawn || second.Trigger.Name != SolarNoon || !second.Time.After(first.Time) {
+		t.Errorf("Events() = %v, %v, want dawn then an hour before noon", first, second)
+	}
+	if err := s.Err(); err != context.Canceled {
+		t.Errorf("Err() = %v, want %v", err, context.Canceled)
+	}
+
+	// without any trigger, the channel is closed at once with the error of Run
+	empty := Scheduler{Observer: obs, Clock: clock}
+	for range empty.Events(context.Background()) {
+		t.Errorf("Events() sent an event without triggers")
+	}
+	if err := empty.Err(); err == nil || err == context.Canceled {
+		t.Errorf("Err() = %v, want the error of Run without triggers", err)
+	}
+}
@@ -0,0 +1,123 @@
+package suncalc
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+type Shadow struct {
+	// Length of the shadow, in the unit of the object height,
+	// infinite when the sun is below the horizon
+	Length float64
+
+	// Direction the shadow points to, in radians, measured from south to west like SunPosition.Azimuth
+	Azimuth float64
+}
+
+// calculates the shadow of a vertical object of the given height for a given date and latitude/longitude
+func GetShadow(date time.Time, lat float64, lng float64, height float64) Shadow {
+	pos := GetPosition(date, lat, lng)
+	result := Shadow{math.Inf(1), normalizeAzimuth(pos.Azimuth + math.Pi)}
+	if pos.Altitude > 0 {
+		result.Length = height / math.Tan(pos.Altitude)
+	}
+	return result
+}
+
+// GroundPoint is a position on a flat ground, in meters towards east (X) and north (Y) of a reference point
+type GroundPoint struct {
+	X, Y float64
+}
+
+// Building is a box standing on a flat ground
+type Building struct {
+	Center GroundPoint
+
+	// Dimensions in meters, the width being along the X axis and the depth along the Y axis before rotation
+	Width, Depth, Height float64
+
+	// Rotation of the building counterclockwise, in radians
+	Rotation float64
+}
+
+// returns the corners of the building on the ground, counterclockwise
+func (b Building) Footprint() []GroundPoint {
+	sin, cos := math.Sincos(b.Rotation)
+	corners := []GroundPoint{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}
+	for i, c := range corners {
+		x, y := c.X*b.Width/2, c.Y*b.Depth/2
+		corners[i] = GroundPoint{b.Center.X + x*cos - y*sin, b.Center.Y + x*sin + y*cos}
+	}
+	return corners
+}
+
+type ShadowPolygon struct {
+	Time time.Time
+
+	// Outline of the ground covered by the building and its shadow, counterclockwise,
+	// empty when the sun is below the horizon
+	Points []GroundPoint
+}
+
+// calculates the shadow of the building on the ground for a given date and latitude/longitude
+func GetBuildingShadow(date time.Time, lat float64, lng float64, b Building) ShadowPolygon {
+	shadow := GetShadow(date, lat, lng, b.Height)
+	if math.IsInf(shadow.Length, 1) {
+		return ShadowPolygon{date, nil}
+	}
+
+	// the shadow points to the azimuth of the shadow, shifted by PI as azimuths start from south
+	dx := -shadow.Length * math.Sin(shadow.Azimuth)
+	dy := -shadow.Length * math.Cos(shadow.Azimuth)
+
+	footprint := b.Footprint()
+	points := append([]GroundPoint(nil), footprint...)
+	for _, p := range footprint {
+		points = append(points, GroundPoint{p.X + dx, p.Y + dy})
+	}
+	return ShadowPolygon{date, convexHull(points)}
+}
+
+// calculates the shadows of the building every step from start to end included
+func GetBuildingShadows(start time.Time, end time.Time, step time.Duration, lat float64, lng float64, b Building) []ShadowPolygon {
+	if step <= 0 {
+		return nil
+	}
+	var result []ShadowPolygon
+	for t := start; !t.After(end); t = t.Add(step) {
+		result = append(result, GetBuildingShadow(t, lat, lng, b))
+	}
+	return result
+}
+
+// returns the convex hull of the points counterclockwise, with the monotone chain algorithm
+func convexHull(points []GroundPoint) []GroundPoint {
+	sorted := append([]GroundPoint(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].X < sorted[j].X || (sorted[i].X == sorted[j].X && sorted[i].Y < sorted[j].Y)
+	})
+	if len(sorted) < 3 {
+		return sorted
+	}
+
+	cross := func(o, a, b GroundPoint) float64 {
+		return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+	}
+
+	hull := make([]GroundPoint, 0, 2*len(sorted))
+	for _, p := range sorted { // lower hull
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	for i, lower := len(sorted)-2, len(hull)+1; i >= 0; i-- { // upper hull
+		p := sorted[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1]
+}
@@ -0,0 +1,89 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetShadow(t *testing.T) {
+	lat, lng := 51.5, -0.1
+
+	tests := []struct {
+		name        string
+		date        time.Time
+		wantAzimuth float64
+	}{
+		{"noon", time.Date(2005, 6, 1, 12, 0, 0, 0, time.UTC), math.Pi},
+		{"morning", time.Date(2005, 6, 1, 6, 0, 0, 0, time.UTC), 0.25 * math.Pi},
+		{"evening", time.Date(2005, 6, 1, 18, 0, 0, 0, time.UTC), -0.25 * math.Pi},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos := GetPosition(tt.date, lat, lng)
+			got := GetShadow(tt.date, lat, lng, 10)
+			if want := 10 / math.Tan(pos.Altitude); math.Abs(got.Length-want) > 1e-9 {
+				t.Errorf("GetShadow() length = %v, want %v", got.Length, want)
+			}
+			// the shadow is opposite to the sun, roughly north at noon, west in the morning and east in the evening
+			if d := math.Abs(normalizeAzimuth(got.Azimuth - tt.wantAzimuth)); d > 0.25*math.Pi {
+				t.Errorf("GetShadow() azimuth = %v, want about %v", got.Azimuth/rad, tt.wantAzimuth/rad)
+			}
+			if d := math.Abs(normalizeAzimuth(got.Azimuth - pos.Azimuth)); math.Abs(d-math.Pi) > 1e-9 {
+				t.Errorf("GetShadow() azimuth = %v, sun azimuth %v", got.Azimuth/rad, pos.Azimuth/rad)
+			}
+		})
+	}
+
+	if got := GetShadow(time.Date(2005, 6, 1, 0, 0, 0, 0, time.UTC), lat, lng, 10); !math.IsInf(got.Length, 1) {
+		t.Errorf("GetShadow() at night = %v, want an infinite length", got.Length)
+	}
+}
+
+// returns the area of the polygon, positive when counterclockwise
+func polygonArea(points []GroundPoint) float64 {
+	var area float64
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		area += p.X*q.Y - q.X*p.Y
+	}
+	return area / 2
+}
+
+func TestGetBuildingShadow(t *testing.T) {
+	lat, lng := 51.5, -0.1
+	noon := time.Date(2005, 6, 1, 12, 0, 0, 0, time.UTC)
+	building := Building{Center: GroundPoint{100, 50}, Width: 20, Depth: 10, Height: 15}
+
+	if got := polygonArea(building.Footprint()); math.Abs(got-200) > 1e-9 {
+		t.Errorf("Footprint() area = %v, want 200", got)
+	}
+	rotated := building
+	rotated.Rotation = math.Pi / 2
+	if got := rotated.Footprint()[0]; math.Abs(got.X-105) > 1e-9 || math.Abs(got.Y-40) > 1e-9 {
+		t.Errorf("Footprint() rotated first corner = %v, want {105 40}", got)
+	}
+
+	shadow := GetBuildingShadow(noon, lat, lng, building)
+	s := GetShadow(noon, lat, lng, building.Height)
+	dx, dy := -s.Length*math.Sin(s.Azimuth), -s.Length*math.Cos(s.Azimuth)
+	// the footprint swept along the shadow vector
+	if got, want := polygonArea(shadow.Points), 200+20*math.Abs(dy)+10*math.Abs(dx); math.Abs(got-want) > 1e-6 {
+		t.Errorf("GetBuildingShadow() area = %v, want about %v", got, want)
+	}
+	var north float64
+	for _, p := range shadow.Points {
+		north = math.Max(north, p.Y)
+	}
+	if math.Abs(north-(55+dy)) > 1e-9 || dy < 0 {
+		t.Errorf("GetBuildingShadow() reaches %v north, want %v", north, 55+dy)
+	}
+
+	shadows := GetBuildingShadows(noon.Add(-12*time.Hour), noon.Add(12*time.Hour), time.Hour, lat, lng, building)
+	if len(shadows) != 25 {
+		t.Fatalf("GetBuildingShadows() returned %d shadows, want 25", len(shadows))
+	}
+	if shadows[0].Points != nil || shadows[12].Points == nil {
+		t.Errorf("GetBuildingShadows() = %v, want no shadow at midnight", shadows)
+	}
+}
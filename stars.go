@@ -0,0 +1,215 @@
+package suncalc
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Star is a fixed star, with its position at the epoch J2000 and its proper motion
+type Star struct {
+	Name string
+
+	// Right ascension and declination at J2000, in radians
+	RightAscension float64
+	Declination    float64
+
+	// Proper motion in right ascension (times the cosine of the declination) and in declination, in radians per year
+	ProperMotionRA  float64
+	ProperMotionDec float64
+
+	// Visual magnitude
+	Magnitude float64
+}
+
+//go:embed stars.csv
+var brightStarsCSV string
+
+var brightStars = mustReadStars(brightStarsCSV)
+
+func mustReadStars(s string) []Star {
+	stars, err := ReadStars(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return stars
+}
+
+// BrightStars returns the embedded catalog of 56 bright stars down to the magnitude 2.5, sorted by magnitude: the
+// brightest stars of the sky, Polaris and most of the navigational stars. It is not complete to any magnitude
+func BrightStars() []Star {
+	return append([]Star(nil), brightStars...)
+}
+
+// GetStar returns the star of the embedded catalog with the given name, case-insensitive
+func GetStar(name string) (Star, bool) {
+	for _, s := range brightStars {
+		if strings.EqualFold(s.Name, name) {
+			return s, true
+		}
+	}
+	return Star{}, false
+}
+
+// ReadStars reads a star catalog from CSV records "name,ra,dec,pmra,pmdec,mag": the right ascension and
+// declination at J2000 in degrees, the proper motions in milliarcseconds per year, the one in right ascension
+// being multiplied by the cosine of the declination as in the Hipparcos catalogue, and the visual magnitude.
+// An optional header line naming these columns, name,ra,dec,pmra,pmdec,mag, and '#' comments are ignored.
+func ReadStars(r io.Reader) ([]Star, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var stars []Star
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 6 {
+			return nil, fmt.Errorf("suncalc: star record %d: expected name, ra, dec, pmra, pmdec and mag", line)
+		}
+		if line == 1 && isStarHeader(record) {
+			continue
+		}
+
+		var values [5]float64
+		for i := range values {
+			if values[i], err = strconv.ParseFloat(strings.TrimSpace(record[i+1]), 64); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("suncalc: star record %d: invalid number", line)
+		}
+
+		mas := rad / 3600000
+		stars = append(stars, Star{strings.TrimSpace(record[0]), values[0] * rad, values[1] * rad, values[2] * mas, values[3] * mas, values[4]})
+	}
+	return stars, nil
+}
+
+// the columns of the header line of ReadStars
+var starColumns = []string{"name", "ra", "dec", "pmra", "pmdec", "mag"}
+
+func isStarHeader(record []string) bool {
+	for i, column := range starColumns {
+		if !strings.EqualFold(strings.TrimSpace(record[i]), column) {
+			return false
+		}
+	}
+	return true
+}
+
+// Coordinates returns the apparent place of the star at the date: its position at J2000 moved by the proper motion,
+// precessed to the date (Meeus 21), and corrected for the nutation and the annual aberration (Meeus 23)
+func (s Star) Coordinates(date time.Time) EquatorialCoordinates {
+	ra, dec := s.apparentPlace(julianCenturiesTT(date))
+	return EquatorialCoordinates{ra, dec, 0}
+}
+
+// the stars are points at an infinite distance
+func (s Star) Radius(date time.Time) float64 {
+	return 0
+}
+
+func (s Star) Parallax(date time.Time) float64 {
+	return 0
+}
+
+// returns the apparent right ascension and declination of the star, T being in Julian centuries of terrestrial time since J2000
+func (s Star) apparentPlace(T float64) (float64, float64) {
+	years := T * 100
+	dec0 := s.Declination + s.ProperMotionDec*years
+	ra0 := s.RightAscension + s.ProperMotionRA*years/math.Cos(s.Declination)
+
+	// precession from J2000 (formulas 21.2 to 21.4)
+	arcsec := rad / 3600
+	zeta := arcsec * T * (2306.2181 + T*(0.30188+T*0.017998))
+	z := arcsec * T * (2306.2181 + T*(1.09468+T*0.018203))
+	theta := arcsec * T * (2004.3109 - T*(0.42665+T*0.041833))
+	sinT, cosT := math.Sincos(theta)
+	sinD, cosD := math.Sincos(dec0)
+	cosA := math.Cos(ra0 + zeta)
+	A := cosD * math.Sin(ra0+zeta)
+	B := cosT*cosD*cosA - sinT*sinD
+	C := sinT*cosD*cosA + cosT*sinD
+	ra := math.Atan2(A, B) + z
+	dec := math.Asin(C)
+
+	// nutation (formula 23.1)
+	dpsi, deps := nutation(T)
+	epsilon := meanObliquity(T) + deps
+	sinE, cosE := math.Sincos(epsilon)
+	sinA, cosA := math.Sincos(ra)
+	tanD := math.Tan(dec)
+	dra := (cosE+sinE*sinA*tanD)*dpsi - cosA*tanD*deps
+	ddec := sinE*cosA*dpsi + sinA*deps
+
+	// annual aberration (formula 23.3), from the true longitude of the sun
+	const kappa = 20.49552 * rad / 3600
+	e := 0.016708634 - T*(0.000042037+T*0.0000001267)
+	perihelion := rad * (102.93735 + T*(1.71946+T*0.00046))
+	sun, _ := accurateSunEcliptic(T)
+	sun -= dpsi
+	sinS, cosS := math.Sincos(sun)
+	sinP, cosP := math.Sincos(perihelion)
+	sinD, cosD = math.Sincos(dec)
+	dra += (-kappa*(cosA*cosS*cosE+sinA*sinS) + e*kappa*(cosA*cosP*cosE+sinA*sinP)) / cosD
+	ddec += -kappa*(cosS*(math.Tan(epsilon)*cosD-sinA*sinD)*cosE+cosA*sinD*sinS) +
+		e*kappa*(cosP*(math.Tan(epsilon)*cosD-sinA*sinD)*cosE+cosA*sinD*sinP)
+
+	return math.Mod(ra+dra+2*math.Pi, 2*math.Pi), dec + ddec
+}
+
+// ObservingWindow is the part of a night during which a body is high enough in a dark sky
+type ObservingWindow struct {
+	Interval
+
+	// Instant of the window at which the body is the highest, and its altitude in radians
+	Best     time.Time
+	Altitude float64
+}
+
+// the sun is below this altitude during the astronomical night
+const astronomicalNight = -18 * rad
+
+// calculates the longest window of the night starting on the observer's local day of the date, during which
+// the sun is below -18° and the body is above both the minimum altitude (in radians) and the observer's
+// horizon profile if any. Returns false when there is no such window
+func GetObservingWindow(date time.Time, obs Observer, body Body, minAltitude float64) (ObservingWindow, bool) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, obs.Location)
+	end := start.AddDate(0, 0, 1)
+	altitude := func(t time.Time) float64 {
+		pos := GetBodyPosition(t, obs.Latitude, obs.Longitude, body)
+		return pos.Altitude - math.Max(minAltitude, obs.horizonAltitude(pos.Azimuth))
+	}
+
+	var result ObservingWindow
+	found := false
+	for _, i := range findIntervals(start, end, horizonStep, func(t time.Time) bool {
+		return GetPosition(t, obs.Latitude, obs.Longitude).Altitude < astronomicalNight && altitude(t) > 0
+	}) {
+		if !found || i.End.Sub(i.Start) > result.End.Sub(result.Start) {
+			result.Interval, found = i, true
+		}
+	}
+	if !found {
+		return result, false
+	}
+
+	result.Best = findMinimum(result.Start, result.End, horizonStep, func(t time.Time) float64 {
+		return -GetBodyPosition(t, obs.Latitude, obs.Longitude, body).Altitude
+	})
+	result.Altitude = GetBodyPosition(result.Best, obs.Latitude, obs.Longitude, body).Altitude
+	return result, true
+}
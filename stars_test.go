@@ -0,0 +1,126 @@
+package suncalc
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStarApparentPlace(t *testing.T) {
+	// examples 21.b and 23.a of "Astronomical Algorithms": theta Persei on 2028 November 13.19 TD
+	star := Star{
+		Name:            "theta Persei",
+		RightAscension:  41.049942 * rad,
+		Declination:     49.228467 * rad,
+		ProperMotionRA:  0.03425 * 15 * math.Cos(49.228467*rad) * rad / 3600,
+		ProperMotionDec: -0.0895 * rad / 3600,
+	}
+	T := (2462088.69 - J2000) / 36525
+	ra, dec := star.apparentPlace(T)
+
+	arcsec := rad / 3600
+	if math.Abs(ra-41.5599646*rad) > 2*arcsec {
+		t.Errorf("right ascension = %v, want 41.5599646", ra/rad)
+	}
+	if math.Abs(dec-49.3520685*rad) > 2*arcsec {
+		t.Errorf("declination = %v, want 49.3520685", dec/rad)
+	}
+}
+
+func TestBrightStars(t *testing.T) {
+	stars := BrightStars()
+	if len(stars) != 56 {
+		t.Fatalf("BrightStars() returned %d stars, want 56", len(stars))
+	}
+	for i, s := range stars {
+		if s.Magnitude > 2.5 || i > 0 && s.Magnitude < stars[i-1].Magnitude {
+			t.Errorf("BrightStars() %v of magnitude %v is out of order or too faint", s.Name, s.Magnitude)
+		}
+	}
+	for _, name := range []string{"Polaris", "Alkaid", "Kaus Australis", "Peacock", "Miaplacidus", "Markab"} {
+		if _, ok := GetStar(name); !ok {
+			t.Errorf("GetStar(%v) found no star", name)
+		}
+	}
+	sirius, ok := GetStar("sirius")
+	if !ok || sirius.Magnitude != -1.46 || math.Abs(sirius.Declination-(-16.716117*rad)) > 1e-6 {
+		t.Errorf("GetStar(sirius) = %+v, %v", sirius, ok)
+	}
+	if _, ok := GetStar("Nibiru"); ok {
+		t.Errorf("GetStar(Nibiru) found a star")
+	}
+
+	if _, err := ReadStars(strings.NewReader("name,ra,dec,pmra,pmdec,mag\nVega,279.2,38.8,200,286\n")); err == nil {
+		t.Errorf("ReadStars() expected an error on a missing column")
+	}
+	if _, err := ReadStars(strings.NewReader("Vega,279.2,38.8,200,286,0.03\nDeneb,north,45.3,2,1.9,1.25\n")); err == nil {
+		t.Errorf("ReadStars() expected an error on an invalid number")
+	}
+	if _, err := ReadStars(strings.NewReader("Deneb,north,45.3,2,1.9,1.25\nVega,279.2,38.8,200,286,0.03\n")); err == nil {
+		t.Errorf("ReadStars() expected an error on an invalid number in the first record")
+	}
+	if stars, err := ReadStars(strings.NewReader("Name, RA, Dec, pmRA, pmDec, Mag\nVega,279.2,38.8,200,286,0.03\n")); err != nil || len(stars) != 1 {
+		t.Errorf("ReadStars() with a header = %v, %v, want one star", stars, err)
+	}
+}
+
+func TestGetBodyTimesStar(t *testing.T) {
+	date := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	paris := Observer{48.85, 2.35, 0, time.UTC, nil}
+
+	polaris, _ := GetStar("Polaris")
+	if got := GetBodyTimes(date, paris, polaris); !got.AlwaysUp {
+		t.Errorf("GetBodyTimes(Polaris) = %+v, want AlwaysUp", got)
+	}
+	canopus, _ := GetStar("Canopus")
+	if got := GetBodyTimes(date, paris, canopus); !got.AlwaysDown {
+		t.Errorf("GetBodyTimes(Canopus) = %+v, want AlwaysDown", got)
+	}
+
+	sirius, _ := GetStar("Sirius")
+	times := GetBodyTimes(date, paris, sirius)
+	if times.Rise.IsZero() || times.Transit.IsZero() || times.Set.IsZero() {
+		t.Fatalf("GetBodyTimes(Sirius) = %+v, want a rise, a transit and a set", times)
+	}
+	// Sirius is seen 34' lower without refraction at its rise, and culminates at 90° - latitude + declination
+	for _, date := range []time.Time{times.Rise, times.Set} {
+		if got := GetBodyPosition(date, paris.Latitude, paris.Longitude, sirius).Altitude; math.Abs(got) > 0.1*rad {
+			t.Errorf("altitude at %v = %v, want about 0", date, got/rad)
+		}
+	}
+	c := sirius.Coordinates(times.Transit)
+	if got, want := GetBodyPosition(times.Transit, paris.Latitude, paris.Longitude, sirius).Altitude, math.Pi/2-paris.Latitude*rad+c.Declination; math.Abs(got-want) > 0.05*rad {
+		t.Errorf("altitude at transit = %v, want %v", got/rad, want/rad)
+	}
+}
+
+func TestGetObservingWindow(t *testing.T) {
+	date := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	paris := Observer{48.85, 2.35, 0, time.UTC, nil}
+
+	vega, _ := GetStar("Vega")
+	window, ok := GetObservingWindow(date, paris, vega, 30*rad)
+	if !ok {
+		t.Fatalf("GetObservingWindow(Vega) found no window")
+	}
+	if window.Best.Before(window.Start) || window.Best.After(window.End) || window.Altitude < 30*rad {
+		t.Errorf("GetObservingWindow(Vega) = %+v", window)
+	}
+	// Vega culminates in the evening in October, before the end of the twilight
+	if window.Best.Sub(window.Start) > time.Minute {
+		t.Errorf("Best = %v, want the start of the window %v", window.Best, window.Start)
+	}
+	if sun := GetPosition(window.Start.Add(-time.Minute), paris.Latitude, paris.Longitude); sun.Altitude < -18*rad {
+		t.Errorf("sun altitude before the window = %v, want above -18", sun.Altitude/rad)
+	}
+
+	canopus, _ := GetStar("Canopus")
+	if _, ok := GetObservingWindow(date, paris, canopus, 0); ok {
+		t.Errorf("GetObservingWindow(Canopus) found a window from Paris")
+	}
+	// no astronomical night in Paris at the summer solstice
+	if _, ok := GetObservingWindow(time.Date(2020, 6, 21, 0, 0, 0, 0, time.UTC), paris, vega, 0); ok {
+		t.Errorf("GetObservingWindow(Vega) found a window in June")
+	}
+}
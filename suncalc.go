@@ -6,6 +6,8 @@ package suncalc
 import (
 	"math"
 	"time"
+
+	"github.com/sixdouglas/suncalc/internal/sphere"
 )
 
 // date/DayTime constants and conversions
@@ -34,25 +36,52 @@ func toDays(date time.Time) float64 { return toJulian(date) - J2000 }
 
 // general calculations for position
 const rad = math.Pi / 180
-const e = rad * 23.4397 // obliquity of the Earth
 
-func rightAscension(l float64, b float64) float64 {
-	return math.Atan2(math.Sin(l)*math.Cos(e)-math.Tan(b)*math.Sin(e), math.Cos(l))
+// returns the nutation in longitude and the true obliquity of the Earth, d days after J2000
+func trueObliquity(d float64) (float64, float64) {
+	T := d / 36525
+	dpsi, deps := nutation(T)
+	return dpsi, meanObliquity(T) + deps
+}
+
+// the true equator and equinox of the date, d days after J2000, computed once for all the
+// coordinates and the sidereal time of an instant
+type equinox struct {
+	d, dpsi, e float64
+}
+
+func trueEquinox(d float64) equinox {
+	dpsi, e := trueObliquity(d)
+	return equinox{d, dpsi, e}
 }
 
-func declination(l float64, b float64) float64 {
-	return math.Asin(math.Sin(b)*math.Cos(e) + math.Cos(b)*math.Sin(e)*math.Sin(l))
+// right ascension and declination of the ecliptic coordinates l and b referred to the mean equinox of the date
+func (q equinox) equatorial(l float64, b float64) (float64, float64) {
+	return sphere.EclipticToEquatorial(l+q.dpsi, b, q.e)
 }
 
 func azimuth(H float64, phi float64, dec float64) float64 {
-	return math.Atan2(math.Sin(H), math.Cos(H)*math.Sin(phi)-math.Tan(dec)*math.Cos(phi))
+	return sphere.Azimuth(H, phi, dec)
 }
 
 func altitude(H float64, phi float64, dec float64) float64 {
-	return math.Asin(math.Sin(phi)*math.Sin(dec) + math.Cos(phi)*math.Cos(dec)*math.Cos(H))
+	return sphere.Altitude(H, phi, dec)
 }
 
-func siderealTime(d float64, lw float64) float64 { return rad*(280.16+360.9856235*d) - lw }
+// mean sidereal time at the longitude lw (formula 12.4 of "Astronomical Algorithms")
+func meanSiderealTime(d float64, lw float64) float64 {
+	T := d / 36525
+	return rad*(280.46061837+360.98564736629*d+T*T*(0.000387933-T/38710000)) - lw
+}
+
+// apparent sidereal time at the longitude lw, corrected by the equation of the equinoxes
+func (q equinox) siderealTime(lw float64) float64 {
+	return meanSiderealTime(q.d, lw) + q.dpsi*math.Cos(q.e)
+}
+
+func siderealTime(d float64, lw float64) float64 {
+	return trueEquinox(d).siderealTime(lw)
+}
 
 func astroRefraction(h float64) float64 {
 	if h < 0.0 {
@@ -69,12 +98,18 @@ func astroRefraction(h float64) float64 {
 func solarMeanAnomalyI(d float64) float64 { return solarMeanAnomalyF(d) }
 func solarMeanAnomalyF(d float64) float64 { return rad * (357.5291 + 0.98560028*d) }
 
-func eclipticLongitude(M float64) float64 {
+// longitude of the perihelion of the Earth, referred to the mean equinox of the date
+func perihelion(d float64) float64 { return rad * (102.9372 + 1.71946*d/36525) }
+
+// constant of the annual aberration, by which the sun is seen behind its true longitude
+const sunAberration = -rad * 20.4898 / 3600
+
+// apparent ecliptic longitude of the sun for the mean anomaly M, d days after J2000
+func eclipticLongitude(M float64, d float64) float64 {
 
 	var C = rad * (1.9148*math.Sin(M) + 0.02*math.Sin(2*M) + 0.0003*math.Sin(3*M)) // equation of center
-	var P = rad * 102.9372                                                         // perihelion of the Earth
 
-	return M + C + P + math.Pi
+	return M + C + perihelion(d) + sunAberration + math.Pi
 }
 
 type DayTimeName string
@@ -118,14 +153,12 @@ type coord struct {
 	rightAscension float64
 }
 
-func sunCoords(d float64) coord {
-	var M = solarMeanAnomalyI(d)
-	var L = eclipticLongitude(M)
+func sunCoords(q equinox) coord {
+	var M = solarMeanAnomalyI(q.d)
+	var L = eclipticLongitude(M, q.d)
+	var ra, dec = q.equatorial(L, 0)
 
-	return coord{
-		declination(L, 0),
-		rightAscension(L, 0),
-	}
+	return coord{dec, ra}
 }
 
 type SunPosition struct {
@@ -138,9 +171,9 @@ func GetPosition(date time.Time, lat float64, lng float64) SunPosition {
 
 	var lw = rad * -lng
 	var phi = rad * lat
-	var d = toDays(date)
-	var c = sunCoords(d)
-	var H = siderealTime(d, lw) - c.rightAscension
+	var q = trueEquinox(toDays(date))
+	var c = sunCoords(q)
+	var H = q.siderealTime(lw) - c.rightAscension
 
 	return SunPosition{
 		azimuth(H, phi, c.declination),
@@ -148,9 +181,12 @@ func GetPosition(date time.Time, lat float64, lng float64) SunPosition {
 	}
 }
 
+// altitude of the center of the sun at the sunrise and the sunset, in degrees
+const sunriseAngle = -0.833
+
 // sun times configuration (angle, morning name, evening name)
 var times = []dayTimeConf{
-	{-0.833, Sunrise, Sunset},
+	{sunriseAngle, Sunrise, Sunset},
 	{-0.3, SunriseEnd, SunsetStart},
 	{-6, Dawn, Dusk},
 	{-12, NauticalDawn, NauticalDusk},
@@ -173,9 +209,6 @@ func approxTransit(Ht float64, lw float64, n float64) float64 {
 func solarTransitJ(ds float64, M float64, L float64) float64 {
 	return J2000 + ds + 0.0053*math.Sin(M) - 0.0069*math.Sin(2*L)
 }
-func hourAngle(h float64, phi float64, d float64) float64 {
-	return math.Acos((math.Sin(h) - math.Sin(phi)*math.Sin(d)) / (math.Cos(phi) * math.Cos(d)))
-}
 func observerAngle(height float64) float64 {
 	if height == 0 {
 		return 0
@@ -183,16 +216,9 @@ func observerAngle(height float64) float64 {
 	return -2.076 * math.Sqrt(height) / 60.0
 }
 
-// returns set DayTime for the given sun altitude
-func getSetJ(h float64, lw float64, phi float64, dec float64, n float64, M float64, L float64) float64 {
-	var w = hourAngle(h, phi, dec)
-	var a = approxTransit(w, lw, n)
-	return solarTransitJ(a, M, L)
-}
-
 // calculates sun times for a given date and latitude/longitude
 func GetTimes(date time.Time, lat float64, lng float64) map[DayTimeName]DayTime {
-	return GetTimesWithObserver(date, Observer{lat, lng, 0, time.UTC})
+	return GetTimesWithObserver(date, Observer{lat, lng, 0, time.UTC, nil})
 }
 
 type Observer struct {
@@ -203,44 +229,108 @@ type Observer struct {
 	Height float64
 
 	Location *time.Location
+
+	// The skyline seen by the observer, used instead of the mathematical horizon by GetBodyTimes,
+	// GetHorizonTimes, GetMoonHorizonTimes, GetMoonTimesWithObserver, GetPlanetTimesWithObserver,
+	// the sunlight functions, GetObservingWindow and the moon of GetDarkSkyWindows, can be left empty.
+	// GetTimesWithObserver, GetAltitudeTimes, GetSunPath and GetTwilightTimeline ignore it
+	Horizon HorizonProfile
 }
 
 // calculates sun times for a given date and latitude/longitude, and,
 // the observer height (in meters) relative to the horizon, you can set it to 0 if unknown
 func GetTimesWithObserver(date time.Time, obs Observer) map[DayTimeName]DayTime {
-	lw := rad * -obs.Longitude
-	phi := rad * obs.Latitude
+	s := newSolarTransit(date, obs)
 
-	dh := observerAngle(obs.Height)
+	var oneTime dayTimeConf
+	result := make(map[DayTimeName]DayTime)
+
+	result[SolarNoon] = DayTime{SolarNoon, s.noon}
+	result[Nadir] = DayTime{Nadir, s.noon.Add(-12 * time.Hour)}
+
+	for i := 0; i < len(times); i++ {
+		oneTime = times[i]
+
+		rise, set := s.riseSet(oneTime.angle)
+
+		result[oneTime.morningName] = DayTime{oneTime.morningName, rise}
+		result[oneTime.eveningName] = DayTime{oneTime.eveningName, set}
+	}
+
+	return result
+}
+
+// calculates the morning and evening times the sun reaches the given altitude (in degrees) for a given date
+// and observer, like GetTimesWithObserver does for the predefined ones. The times are zero if the sun
+// does not reach that altitude during the day.
+func GetAltitudeTimes(date time.Time, obs Observer, angle float64) (time.Time, time.Time) {
+	return newSolarTransit(date, obs).riseSet(angle)
+}
+
+// solar transit of the day, around which the sun times are searched
+type solarTransit struct {
+	obs  Observer
+	noon time.Time
+
+	// altitude of the upper edge of the sun over the flat horizon, with the refraction, cached for the
+	// instants sampled by the search of every angle
+	samples map[int64]float64
+}
+
+func (s solarTransit) upperEdge(t time.Time) float64 {
+	if h, ok := s.samples[t.UnixNano()]; ok {
+		return h
+	}
+	c := GetSunCoordinates(t)
+	phi := rad * s.obs.Latitude
+	h := altitude(siderealTime(toDays(t), rad*-s.obs.Longitude)-c.RightAscension, phi, c.Declination)
+	h += math.Asin(sunRadiusKm/c.Distance) - math.Asin(earthRadiusKm/c.Distance)*math.Cos(h) + horizonRefraction
+	s.samples[t.UnixNano()] = h
+	return h
+}
+
+func newSolarTransit(date time.Time, obs Observer) solarTransit {
+	lw := rad * -obs.Longitude
 
 	d := toDays(date)
 	n := julianCycle(d, lw)
 	ds := approxTransit(0, lw, n)
 
 	M := solarMeanAnomalyF(ds)
-	L := eclipticLongitude(M)
-	dec := declination(L, 0)
-
+	L := eclipticLongitude(M, ds)
 	Jnoon := solarTransitJ(ds, M, L)
 
-	var oneTime dayTimeConf
-	result := make(map[DayTimeName]DayTime)
-
-	result[SolarNoon] = DayTime{SolarNoon, fromJulian(Jnoon, obs.Location)}
-	result[Nadir] = DayTime{Nadir, fromJulian(Jnoon-0.5, obs.Location)}
+	// the formula above approximates the equation of time, the transit is refined
+	// until the hour angle of the sun is zero, consistently with GetPosition
+	for i := 0; i < 2; i++ {
+		q := trueEquinox(Jnoon - J2000)
+		H := q.siderealTime(lw) - sunCoords(q).rightAscension
+		Jnoon -= math.Remainder(H, 2*math.Pi) / (2 * math.Pi)
+	}
 
-	for i := 0; i < len(times); i++ {
-		oneTime = times[i]
-		h0 := (oneTime.angle + dh) * rad
+	return solarTransit{obs, fromJulian(Jnoon, obs.Location), make(map[int64]float64)}
+}
 
-		Jset := getSetJ(h0, lw, phi, dec, n, M, L)
-		Jrise := Jnoon - (Jset - Jnoon)
+// returns the last instant the sun reaches the altitude angle (in degrees) in the half day before the transit,
+// and the first one in the half day after it, zero when it does not. The sun is found by the solver of
+// GetBodyTimes over a flat horizon lowered by the observer's height: at -0.833° its upper edge is on the
+// horizon with the refraction of GetBodyTimes, and the other angles are offsets from this sunrise and sunset
+func (s solarTransit) riseSet(angle float64) (time.Time, time.Time) {
+	offset := (angle - sunriseAngle + observerAngle(s.obs.Height)) * rad
+	f := func(t time.Time) float64 { return s.upperEdge(t) - offset }
 
-		result[oneTime.morningName] = DayTime{oneTime.morningName, fromJulian(Jrise, obs.Location)}
-		result[oneTime.eveningName] = DayTime{oneTime.eveningName, fromJulian(Jset, obs.Location)}
+	var rise, set time.Time
+	for _, c := range findCrossings(s.noon.Add(-12*time.Hour), s.noon, horizonStep, f) {
+		if c.rising {
+			rise = c.time
+		}
 	}
-
-	return result
+	for _, c := range findCrossings(s.noon, s.noon.Add(12*time.Hour), horizonStep, f) {
+		if !c.rising && set.IsZero() {
+			set = c.time
+		}
+	}
+	return rise, set
 }
 
 type moonCoordinates struct {
@@ -250,7 +340,8 @@ type moonCoordinates struct {
 }
 
 // moon calculations, based on http://aa.quae.nl/en/reken/hemelpositie.html formulas
-func moonCoords(d float64) moonCoordinates { // geocentric ecliptic coordinates of the moon
+func moonCoords(q equinox) moonCoordinates { // geocentric ecliptic coordinates of the moon
+	d := q.d
 	L := rad * (218.316 + 13.176396*d) // ecliptic longitude
 	M := rad * (134.963 + 13.064993*d) // mean anomaly
 	F := rad * (93.272 + 13.229350*d)  // mean distance
@@ -259,11 +350,8 @@ func moonCoords(d float64) moonCoordinates { // geocentric ecliptic coordinates
 	b := rad * 5.128 * math.Sin(F)   // latitude
 	dt := 385001 - 20905*math.Cos(M) // distance to the moon in km
 
-	return moonCoordinates{
-		rightAscension(l, b),
-		declination(l, b),
-		dt,
-	}
+	ra, dec := q.equatorial(l, b)
+	return moonCoordinates{ra, dec, dt}
 }
 
 type MoonPosition struct {
@@ -276,13 +364,12 @@ type MoonPosition struct {
 func GetMoonPosition(date time.Time, lat float64, lng float64) MoonPosition {
 	lw := rad * -lng
 	phi := rad * lat
-	d := toDays(date)
+	q := trueEquinox(toDays(date))
 
-	c := moonCoords(d)
-	H := siderealTime(d, lw) - c.rightAscension
+	c := moonCoords(q)
+	H := q.siderealTime(lw) - c.rightAscension
 	h := altitude(H, phi, c.declination)
-	// formula 14.1 of "Astronomical Algorithms" 2nd edition by Jean Meeus (Willmann-Bell, Richmond) 1998.
-	pa := math.Atan2(math.Sin(H), math.Tan(phi)*math.Cos(c.declination)-math.Sin(c.declination)*math.Cos(H))
+	pa := sphere.ParallacticAngle(H, phi, c.declination)
 	h = h + astroRefraction(h) // altitude correction for refraction
 
 	return MoonPosition{
@@ -304,9 +391,9 @@ type MoonIllumination struct {
 // Chapter 48 of "Astronomical Algorithms" 2nd edition by Jean Meeus (Willmann-Bell, Richmond) 1998.
 func GetMoonIllumination(date time.Time) MoonIllumination {
 
-	d := toDays(date)
-	s := sunCoords(d)
-	m := moonCoords(d)
+	q := trueEquinox(toDays(date))
+	s := sunCoords(q)
+	m := moonCoords(q)
 
 	sdist := 149598000. // distance from Earth to Sun in km
 
@@ -325,10 +412,6 @@ func GetMoonIllumination(date time.Time) MoonIllumination {
 	}
 }
 
-func hoursLater(date time.Time, h int64) time.Time {
-	return date.Add(time.Duration(h * dayMs / 24 * millyToNano))
-}
-
 type MoonTimes struct {
 	Rise       time.Time
 	Set        time.Time
@@ -336,96 +419,17 @@ type MoonTimes struct {
 	AlwaysDown bool
 }
 
-// calculations for moon rise/set times are based on http://www.stargazing.net/kepler/moonrise.html article
+// calculates the moon rise and set times for a given date and latitude/longitude, in UTC or in the location of the date
 func GetMoonTimes(date time.Time, lat float64, lng float64, inUTC bool) MoonTimes {
 	if inUTC {
-		return GetMoonTimesWithObserver(date, Observer{lat, lng, 0, time.UTC})
+		return GetMoonTimesWithObserver(date, Observer{lat, lng, 0, time.UTC, nil})
 	}
-	return GetMoonTimesWithObserver(date, Observer{lat, lng, 0, date.Location()})
+	return GetMoonTimesWithObserver(date, Observer{lat, lng, 0, date.Location(), nil})
 }
 
-// calculations for moon rise/set times are based on http://www.stargazing.net/kepler/moonrise.html article
+// calculates the moon rise and set times for the observer's local day, over the observer's horizon profile if any.
+// Same as GetBodyTimes for the Moon
 func GetMoonTimesWithObserver(date time.Time, obs Observer) MoonTimes {
-	t := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, obs.Location)
-
-	dh := observerAngle(obs.Height)
-
-	hc := (0.133 + dh) * rad
-	h0 := GetMoonPosition(t, obs.Latitude, obs.Longitude).Altitude - hc
-	var ye float64
-	var x1 float64
-	var x2 float64
-	var rise float64
-	var set float64
-
-	// go in 2-hour chunks, each DayTime seeing if a 3-point quadratic curve crosses zero (which means rise or set)
-	i := int64(1)
-	for i <= 24 {
-
-		h1 := GetMoonPosition(hoursLater(t, i), obs.Latitude, obs.Longitude).Altitude - hc
-		h2 := GetMoonPosition(hoursLater(t, i+1), obs.Latitude, obs.Longitude).Altitude - hc
-		a := (h0+h2)/2 - h1
-		b := (h2 - h0) / 2
-		xe := -b / (2 * a)
-		ye = (a*xe+b)*xe + h1
-		d := b*b - 4*a*h1
-		roots := 0
-		if d >= 0 {
-			dx := math.Sqrt(d) / (math.Abs(a) * 2)
-			x1 = xe - dx
-			x2 = xe + dx
-			if math.Abs(x1) <= 1 {
-				roots++
-			}
-			if math.Abs(x2) <= 1 {
-				roots++
-			}
-			if x1 < -1 {
-				x1 = x2
-			}
-		}
-
-		if roots == 1 {
-			if h0 < 0 {
-				rise = float64(i) + x1
-			} else {
-				set = float64(i) + x1
-			}
-
-		} else {
-			if roots == 2 {
-				if ye < 0 {
-					rise = float64(i) + x2
-					set = float64(i) + x1
-				} else {
-					rise = float64(i) + x1
-					set = float64(i) + x2
-				}
-			}
-		}
-		if rise != 0 && set != 0 {
-			break
-		}
-
-		h0 = h2
-		i += 2
-	}
-
-	var result = MoonTimes{}
-
-	if rise != 0 {
-		result.Rise = hoursLater(t, int64(rise))
-	}
-	if set != 0 {
-		result.Set = hoursLater(t, int64(set))
-	}
-	if rise == 0 && set == 0 {
-		if ye > 0 {
-			result.AlwaysUp = true
-		} else {
-			result.AlwaysDown = true
-		}
-	}
-
-	return result
+	t := GetBodyTimes(date, obs, Moon)
+	return MoonTimes{t.Rise, t.Set, t.AlwaysUp, t.AlwaysDown}
 }
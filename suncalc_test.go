@@ -1,6 +1,7 @@
 package suncalc
 
 import (
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -27,22 +28,22 @@ func TestGetTimes(t *testing.T) {
 				height: 0,
 			},
 			map[DayTimeName]DayTime{
-				Dawn:          {Dawn, time.Date(2020, 5, 16, 21, 29, 45, 597757952, time.UTC)},
-				Dusk:          {Dusk, time.Date(2020, 5, 17, 15, 22, 12, 177163520, time.UTC)},
-				GoldenHour:    {GoldenHour, time.Date(2020, 5, 17, 13, 37, 57, 784171520, time.UTC)},
-				GoldenHourEnd: {GoldenHourEnd, time.Date(2020, 5, 16, 23, 13, 59, 990749952, time.UTC)},
-				Nadir:         {Nadir, time.Date(2020, 5, 16, 18, 25, 58, 887460864, time.UTC)},
-				NauticalDawn:  {NauticalDawn, time.Date(2020, 5, 16, 20, 16, 15, 785748736, time.UTC)},
-				NauticalDusk:  {NauticalDusk, time.Date(2020, 5, 17, 16, 35, 41, 989172992, time.UTC)},
+				Dawn:          {Dawn, time.Date(2020, 5, 16, 21, 28, 28, 697587254, time.UTC)},
+				Dusk:          {Dusk, time.Date(2020, 5, 17, 15, 22, 14, 869462254, time.UTC)},
+				GoldenHour:    {GoldenHour, time.Date(2020, 5, 17, 13, 37, 27, 760087254, time.UTC)},
+				GoldenHourEnd: {GoldenHourEnd, time.Date(2020, 5, 16, 23, 12, 34, 791337254, time.UTC)},
+				Nadir:         {Nadir, time.Date(2020, 5, 16, 18, 24, 35, 201493504, time.UTC)},
+				NauticalDawn:  {NauticalDawn, time.Date(2020, 5, 16, 20, 15, 13, 580399754, time.UTC)},
+				NauticalDusk:  {NauticalDusk, time.Date(2020, 5, 17, 16, 36, 49, 88212254, time.UTC)},
 
 				Night:    {Night, time.Time{}},
 				NightEnd: {NightEnd, time.Time{}},
 
-				SolarNoon:   {SolarNoon, time.Date(2020, 5, 17, 6, 25, 58, 887460864, time.UTC)},
-				Sunrise:     {Sunrise, time.Date(2020, 5, 16, 22, 18, 13, 487034624, time.UTC)},
-				SunriseEnd:  {SunriseEnd, time.Date(2020, 5, 16, 22, 22, 50, 376259072, time.UTC)},
-				Sunset:      {Sunset, time.Date(2020, 5, 17, 14, 33, 44, 287886848, time.UTC)},
-				SunsetStart: {SunsetStart, time.Date(2020, 5, 17, 14, 29, 07, 398662656, time.UTC)},
+				SolarNoon:   {SolarNoon, time.Date(2020, 5, 17, 6, 24, 35, 201493504, time.UTC)},
+				Sunrise:     {Sunrise, time.Date(2020, 5, 16, 22, 16, 52, 17899754, time.UTC)},
+				SunriseEnd:  {SunriseEnd, time.Date(2020, 5, 16, 22, 21, 28, 580399754, time.UTC)},
+				Sunset:      {Sunset, time.Date(2020, 5, 17, 14, 33, 27, 525712254, time.UTC)},
+				SunsetStart: {SunsetStart, time.Date(2020, 5, 17, 14, 28, 49, 205399754, time.UTC)},
 			},
 		},
 		{
@@ -54,30 +55,69 @@ func TestGetTimes(t *testing.T) {
 				height: 0,
 			},
 			map[DayTimeName]DayTime{
-				Dawn:          {Dawn, time.Date(2020, 5, 17, 3, 17, 8, 991038976, time.UTC)},
-				Dusk:          {Dusk, time.Date(2020, 5, 17, 20, 15, 20, 984436480, time.UTC)},
-				GoldenHour:    {GoldenHour, time.Date(2020, 5, 17, 18, 45, 30, 187927296, time.UTC)},
-				GoldenHourEnd: {GoldenHourEnd, time.Date(2020, 5, 17, 4, 46, 59, 787548672, time.UTC)},
-				Nadir:         {Nadir, time.Date(2020, 5, 16, 23, 46, 14, 987737856, time.UTC)},
-				NauticalDawn:  {NauticalDawn, time.Date(2020, 5, 17, 2, 21, 40, 781048832, time.UTC)},
-				NauticalDusk:  {NauticalDusk, time.Date(2020, 5, 17, 21, 10, 49, 194426880, time.UTC)},
+				Dawn:          {Dawn, time.Date(2020, 5, 17, 3, 15, 48, 322007050, time.UTC)},
+				Dusk:          {Dusk, time.Date(2020, 5, 17, 20, 15, 2, 32944550, time.UTC)},
+				GoldenHour:    {GoldenHour, time.Date(2020, 5, 17, 18, 44, 50, 314194550, time.UTC)},
+				GoldenHourEnd: {GoldenHourEnd, time.Date(2020, 5, 17, 4, 45, 34, 259507050, time.UTC)},
+				Nadir:         {Nadir, time.Date(2020, 5, 16, 23, 44, 51, 193100800, time.UTC)},
+				NauticalDawn:  {NauticalDawn, time.Date(2020, 5, 17, 2, 20, 26, 56382050, time.UTC)},
+				NauticalDusk:  {NauticalDusk, time.Date(2020, 5, 17, 21, 10, 55, 939194550, time.UTC)},
 
-				Night:    {Night, time.Date(2020, 5, 17, 22, 31, 59, 413715456, time.UTC)},
-				NightEnd: {NightEnd, time.Date(2020, 05, 17, 1, 0, 30, 561760000, time.UTC)},
+				Night:    {Night, time.Date(2020, 5, 17, 22, 33, 59, 923569550, time.UTC)},
+				NightEnd: {NightEnd, time.Date(2020, 5, 17, 0, 59, 40, 353257050, time.UTC)},
 
-				SolarNoon:   {SolarNoon, time.Date(2020, 5, 17, 11, 46, 14, 987737856, time.UTC)},
-				Sunrise:     {Sunrise, time.Date(2020, 5, 17, 3, 57, 59, 507258624, time.UTC)},
-				SunriseEnd:  {SunriseEnd, time.Date(2020, 5, 17, 4, 1, 58, 918955264, time.UTC)},
-				Sunset:      {Sunset, time.Date(2020, 5, 17, 19, 34, 30, 468217088, time.UTC)},
-				SunsetStart: {SunsetStart, time.Date(2020, 5, 17, 19, 30, 31, 56520192, time.UTC)},
+				SolarNoon:   {SolarNoon, time.Date(2020, 5, 17, 11, 44, 51, 193100800, time.UTC)},
+				Sunrise:     {Sunrise, time.Date(2020, 5, 17, 3, 56, 36, 368882050, time.UTC)},
+				SunriseEnd:  {SunriseEnd, time.Date(2020, 5, 17, 4, 0, 35, 431382050, time.UTC)},
+				Sunset:      {Sunset, time.Date(2020, 5, 17, 19, 33, 59, 923569550, time.UTC)},
+				SunsetStart: {SunsetStart, time.Date(2020, 5, 17, 19, 29, 59, 689194550, time.UTC)},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := GetTimesWithObserver(tt.args.date, Observer{tt.args.lat, tt.args.lng, tt.args.height, time.UTC}); !reflect.DeepEqual(got, tt.want) {
+			if got := GetTimesWithObserver(tt.args.date, Observer{tt.args.lat, tt.args.lng, tt.args.height, time.UTC, nil}); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("GetTimes() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestGetTimesSolarNoon(t *testing.T) {
+	// the solar noon is the transit of the sun of GetPosition, to about a second (15" of hour angle)
+	for _, lng := range []float64{-122.4, 0, 2.35, 151.2} {
+		for date := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC); date.Year() == 2024; date = date.AddDate(0, 1, 0) {
+			noon := GetTimes(date, 40, lng)[SolarNoon].Value
+			d := toDays(noon)
+			if H := math.Remainder(siderealTime(d, rad*-lng)-sunCoords(trueEquinox(d)).rightAscension, 2*math.Pi); math.Abs(H) > 15*rad/3600 {
+				t.Errorf("hour angle of the sun at the solar noon of %v at %v = %v\"", date, lng, H/rad*3600)
+			}
+		}
+	}
+}
+
+func TestGetAltitudeTimes(t *testing.T) {
+	date := time.Date(2020, 5, 17, 15, 05, 16, 414278, time.UTC)
+	obs := Observer{50.700078, 2.891449, 0, time.UTC, nil}
+	times := GetTimesWithObserver(date, obs)
+
+	tests := []struct {
+		angle   float64
+		morning DayTimeName
+		evening DayTimeName
+	}{
+		{-0.833, Sunrise, Sunset},
+		{-6, Dawn, Dusk},
+		{6, GoldenHourEnd, GoldenHour},
+	}
+	for _, tt := range tests {
+		morning, evening := GetAltitudeTimes(date, obs, tt.angle)
+		if !morning.Equal(times[tt.morning].Value) || !evening.Equal(times[tt.evening].Value) {
+			t.Errorf("GetAltitudeTimes(%v) = %v, %v, want %v, %v", tt.angle, morning, evening, times[tt.morning].Value, times[tt.evening].Value)
+		}
+	}
+
+	if morning, evening := GetAltitudeTimes(date, obs, 70); !morning.IsZero() || !evening.IsZero() {
+		t.Errorf("GetAltitudeTimes(70) = %v, %v, want zero times", morning, evening)
+	}
+}
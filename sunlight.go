@@ -0,0 +1,77 @@
+package suncalc
+
+import (
+	"time"
+)
+
+// Obstruction is the outline of an obstacle (building, tree, ...) as seen by the observer,
+// each vertex being a direction of the sky. Obstructions must be less than half a turn wide.
+type Obstruction []HorizonPoint
+
+// reports whether the direction of the sky is hidden by the obstruction
+func (o Obstruction) Contains(azimuth float64, altitude float64) bool {
+	if len(o) < 3 {
+		return false
+	}
+
+	// unwrap the azimuths around the obstruction, and bring the tested one close to it
+	xs := make([]float64, len(o))
+	xs[0] = o[0].Azimuth
+	center := xs[0]
+	for i := 1; i < len(o); i++ {
+		xs[i] = xs[i-1] + normalizeAzimuth(o[i].Azimuth-o[i-1].Azimuth)
+		center += xs[i]
+	}
+	center /= float64(len(o))
+	x := center + normalizeAzimuth(azimuth-center)
+
+	// even-odd rule
+	inside := false
+	for i := range o {
+		j := (i + 1) % len(o)
+		if (o[i].Altitude > altitude) != (o[j].Altitude > altitude) {
+			cross := xs[i] + (altitude-o[i].Altitude)/(o[j].Altitude-o[i].Altitude)*(xs[j]-xs[i])
+			if cross > x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// reports whether the sun center is visible by the observer, over the horizon and the obstructions
+func sunVisible(date time.Time, obs Observer, obstructions []Obstruction) bool {
+	pos := GetPosition(date, obs.Latitude, obs.Longitude)
+	if pos.Altitude <= obs.horizonAltitude(pos.Azimuth) {
+		return false
+	}
+	for _, o := range obstructions {
+		if o.Contains(pos.Azimuth, pos.Altitude) {
+			return false
+		}
+	}
+	return true
+}
+
+// calculates the intervals from start to end during which the observer receives direct
+// sunlight, the sun being above the observer's horizon and hidden by none of the obstructions
+func GetSunlightIntervals(start time.Time, end time.Time, obs Observer, obstructions []Obstruction) []Interval {
+	return findIntervals(start, end, horizonStep, func(t time.Time) bool {
+		return sunVisible(t, obs, obstructions)
+	})
+}
+
+// calculates the duration of direct sunlight received by the observer from start to end
+func GetSunlightDuration(start time.Time, end time.Time, obs Observer, obstructions []Obstruction) time.Duration {
+	var result time.Duration
+	for _, i := range GetSunlightIntervals(start, end, obs, obstructions) {
+		result += i.Duration()
+	}
+	return result
+}
+
+// calculates the duration of direct sunlight received by the observer during its local day
+func GetDailySunlightDuration(date time.Time, obs Observer, obstructions []Obstruction) time.Duration {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, obs.Location)
+	return GetSunlightDuration(start, start.AddDate(0, 0, 1), obs, obstructions)
+}
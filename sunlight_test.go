@@ -0,0 +1,109 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestObstructionContains(t *testing.T) {
+	box := Obstruction{{-30 * rad, 0}, {30 * rad, 0}, {30 * rad, 20 * rad}, {-30 * rad, 20 * rad}}
+	north := Obstruction{{170 * rad, 0}, {-170 * rad, 0}, {-170 * rad, 10 * rad}, {170 * rad, 10 * rad}}
+
+	tests := []struct {
+		name        string
+		obstruction Obstruction
+		azimuth     float64
+		altitude    float64
+		want        bool
+	}{
+		{"inside", box, 0, 10 * rad, true},
+		{"above", box, 0, 25 * rad, false},
+		{"beside", box, 40 * rad, 10 * rad, false},
+		{"opposite", box, math.Pi, 10 * rad, false},
+		{"across north", north, math.Pi, 5 * rad, true},
+		{"across north, negative azimuth", north, -175 * rad, 5 * rad, true},
+		{"opposite of north", north, 0, 5 * rad, false},
+		{"degenerated", Obstruction{{0, 0}, {0, 1}}, 0, 0.5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.obstruction.Contains(tt.azimuth, tt.altitude); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSunlightIntervals(t *testing.T) {
+	obs := Observer{Latitude: 51.5, Longitude: -0.1, Location: time.UTC}
+	start := time.Date(2021, 3, 21, 0, 0, 0, 0, time.UTC)
+
+	open := GetSunlightIntervals(start, start.AddDate(0, 0, 1), obs, nil)
+	if len(open) != 1 {
+		t.Fatalf("GetSunlightIntervals() = %v, want a single interval", open)
+	}
+	for _, edge := range []time.Time{open[0].Start, open[0].End} {
+		if alt := GetPosition(edge, obs.Latitude, obs.Longitude).Altitude; math.Abs(alt) > 0.001*rad {
+			t.Errorf("GetSunlightIntervals() edge %v at altitude %v, want 0", edge, alt/rad)
+		}
+	}
+	// about 12 hours at the equinox
+	if d := open[0].Duration(); d < 11*time.Hour+50*time.Minute || d > 12*time.Hour+10*time.Minute {
+		t.Errorf("GetSunlightIntervals() duration = %v, want about 12h", d)
+	}
+
+	// a building hides the sun at the middle of the day
+	building := Obstruction{{-20 * rad, 0}, {20 * rad, 0}, {20 * rad, 60 * rad}, {-20 * rad, 60 * rad}}
+	hidden := GetSunlightIntervals(start, start.AddDate(0, 0, 1), obs, []Obstruction{building})
+	if len(hidden) != 2 {
+		t.Fatalf("GetSunlightIntervals() = %v, want two intervals", hidden)
+	}
+	for _, edge := range []time.Time{hidden[0].End, hidden[1].Start} {
+		if az := GetPosition(edge, obs.Latitude, obs.Longitude).Azimuth; math.Abs(math.Abs(az)-20*rad) > 0.01*rad {
+			t.Errorf("GetSunlightIntervals() edge %v at azimuth %v, want +/-20", edge, az/rad)
+		}
+	}
+}
+
+func TestGetSunlightDuration(t *testing.T) {
+	obs := Observer{Latitude: 51.5, Longitude: -0.1, Location: time.UTC}
+	date := time.Date(2021, 3, 21, 0, 0, 0, 0, time.UTC)
+
+	open := GetDailySunlightDuration(date, obs, nil)
+	if got := GetSunlightDuration(date, date.AddDate(0, 0, 1), obs, nil); got != open {
+		t.Errorf("GetSunlightDuration() = %v, want %v", got, open)
+	}
+
+	// a window facing south-east, with a 30 degrees high ridge behind the house, from the south-west to the north
+	obs.Horizon = NewHorizonProfile([]HorizonPoint{{-90 * rad, 0}, {45 * rad, 0}, {50 * rad, 30 * rad}, {-170 * rad, 30 * rad}})
+	window := GetDailySunlightDuration(date, obs, nil)
+	if window <= 0 || window >= open-3*time.Hour {
+		t.Errorf("GetDailySunlightDuration() = %v, want less than %v", window, open-3*time.Hour)
+	}
+
+	// in June, the sun is still seen over the ridge, and disappears behind it at 30 degrees
+	june := time.Date(2021, 6, 21, 0, 0, 0, 0, time.UTC)
+	intervals := GetSunlightIntervals(june, june.AddDate(0, 0, 1), obs, nil)
+	if len(intervals) != 1 {
+		t.Fatalf("GetSunlightIntervals() in June = %v, want one interval", intervals)
+	}
+	if pos := GetPosition(intervals[0].End, obs.Latitude, obs.Longitude); pos.Azimuth < 50*rad || math.Abs(pos.Altitude-30*rad) > 0.01*rad {
+		t.Errorf("GetSunlightIntervals() in June ends at azimuth %v and altitude %v, want behind the ridge at 30", pos.Azimuth/rad, pos.Altitude/rad)
+	}
+
+	var days time.Duration
+	for i := 0; i < 7; i++ {
+		days += GetDailySunlightDuration(date.AddDate(0, 0, i), obs, nil)
+	}
+	week := GetSunlightDuration(date, date.AddDate(0, 0, 7), obs, nil)
+	if d := week - days; d < -5*time.Second || d > 5*time.Second {
+		t.Errorf("GetSunlightDuration() over a week = %v, want %v", week, days)
+	}
+
+	east := Obstruction{{-179 * rad, -90 * rad}, {0, -90 * rad}, {0, 90 * rad}, {-179 * rad, 90 * rad}}
+	west := Obstruction{{0, -90 * rad}, {179 * rad, -90 * rad}, {179 * rad, 90 * rad}, {0, 90 * rad}}
+	if got := GetDailySunlightDuration(date, obs, []Obstruction{east, west}); got != 0 {
+		t.Errorf("GetDailySunlightDuration() = %v, want 0", got)
+	}
+}
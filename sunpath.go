@@ -0,0 +1,155 @@
+package suncalc
+
+import (
+	"math"
+	"time"
+)
+
+type SunPathPoint struct {
+	Time     time.Time
+	Azimuth  float64
+	Altitude float64
+}
+
+// number of incremental steps after which the iterator is computed again from scratch,
+// to keep rounding errors from piling up
+const sunIteratorReseed = 1000
+
+// sunIterator computes successive sun positions for a fixed observer. Mean anomaly and
+// sidereal time grow linearly with time, so their sine and cosine are advanced with a
+// rotation instead of being computed again at every step. The nutation, the obliquity and
+// the perihelion vary slowly: they are computed when seeding, and followed linearly over a day.
+type sunIterator struct {
+	sinPhi, cosPhi float64
+	lw             float64
+
+	d                  float64
+	sinM, cosM         float64 // solar mean anomaly
+	sinTheta, cosTheta float64 // mean sidereal time
+
+	dd                   float64 // last step, in days
+	sinDM, cosDM         float64
+	sinDTheta, cosDTheta float64
+	steps                int
+
+	d0                     float64 // instant of the last seed
+	dpsi, e, p             float64 // nutation in longitude, true obliquity and perihelion at d0
+	dpsiRate, eRate, pRate float64 // and their change per day
+}
+
+func newSunIterator(date time.Time, lat float64, lng float64) *sunIterator {
+	it := &sunIterator{lw: rad * -lng}
+	it.sinPhi, it.cosPhi = math.Sincos(rad * lat)
+	it.seed(toDays(date))
+	return it
+}
+
+func (it *sunIterator) seed(d float64) {
+	it.d = d
+	it.sinM, it.cosM = math.Sincos(solarMeanAnomalyF(d))
+	it.sinTheta, it.cosTheta = math.Sincos(meanSiderealTime(d, it.lw))
+	it.steps = 0
+
+	it.d0 = d
+	it.dpsi, it.e = trueObliquity(d)
+	it.p = perihelion(d)
+	dpsi1, e1 := trueObliquity(d + 1)
+	it.dpsiRate, it.eRate, it.pRate = dpsi1-it.dpsi, e1-it.e, perihelion(d+1)-it.p
+}
+
+// moves the iterator dd days forward
+func (it *sunIterator) advance(dd float64) {
+	it.steps++
+	if it.steps >= sunIteratorReseed || it.d+dd-it.d0 > 1 {
+		it.seed(it.d + dd)
+		return
+	}
+
+	if dd != it.dd {
+		it.dd = dd
+		it.sinDM, it.cosDM = math.Sincos(rad * 0.98560028 * dd)
+		it.sinDTheta, it.cosDTheta = math.Sincos(rad * 360.98564736629 * dd)
+	}
+
+	it.d += dd
+	it.sinM, it.cosM = it.sinM*it.cosDM+it.cosM*it.sinDM, it.cosM*it.cosDM-it.sinM*it.sinDM
+	it.sinTheta, it.cosTheta = it.sinTheta*it.cosDTheta+it.cosTheta*it.sinDTheta, it.cosTheta*it.cosDTheta-it.sinTheta*it.sinDTheta
+}
+
+// same as GetPosition for the current instant of the iterator
+func (it *sunIterator) position() SunPosition {
+	dt := it.d - it.d0
+	dpsi := it.dpsi + it.dpsiRate*dt
+	sinE, cosE := math.Sincos(it.e + it.eRate*dt)
+	sinP, cosP := math.Sincos(it.p + it.pRate*dt + sunAberration + dpsi) // on the true equinox
+
+	// apparent sidereal time, corrected by the equation of the equinoxes
+	sinEq, cosEq := math.Sincos(dpsi * cosE)
+	sinTheta := it.sinTheta*cosEq + it.cosTheta*sinEq
+	cosTheta := it.cosTheta*cosEq - it.sinTheta*sinEq
+
+	sin2M := 2 * it.sinM * it.cosM
+	sin3M := it.sinM * (3 - 4*it.sinM*it.sinM)
+	C := rad * (1.9148*it.sinM + 0.02*sin2M + 0.0003*sin3M) // equation of center
+	sinC, cosC := math.Sincos(C)
+
+	// L = M + C + P + PI
+	sinA := -(it.sinM*cosP + it.cosM*sinP)
+	cosA := -(it.cosM*cosP - it.sinM*sinP)
+	sinL := sinA*cosC + cosA*sinC
+	cosL := cosA*cosC - sinA*sinC
+
+	// declination and hour angle, the latter scaled by cos(declination)
+	sinDec := sinL * sinE
+	cosH := cosTheta*cosL + sinTheta*sinL*cosE
+	sinH := sinTheta*cosL - cosTheta*sinL*cosE
+
+	return SunPosition{
+		math.Atan2(sinH, cosH*it.sinPhi-sinDec*it.cosPhi),
+		math.Asin(it.sinPhi*sinDec + it.cosPhi*cosH),
+	}
+}
+
+// samples the sun position every step over the observer's local day, from midnight
+// to the next midnight included
+func GetSunPath(date time.Time, obs Observer, step time.Duration) []SunPathPoint {
+	if step <= 0 {
+		return nil
+	}
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, obs.Location)
+	end := start.AddDate(0, 0, 1)
+
+	var result []SunPathPoint
+	it := newSunIterator(start, obs.Latitude, obs.Longitude)
+	dd := float64(step) / float64(24*time.Hour)
+	for t := start; !t.After(end); t = t.Add(step) {
+		if !t.Equal(start) {
+			it.advance(dd)
+		}
+		pos := it.position()
+		result = append(result, SunPathPoint{t, pos.Azimuth, pos.Altitude})
+	}
+
+	return result
+}
+
+// samples the sun position every day of the year at the same clock time of the
+// observer's location, the points draw the analemma
+func GetAnalemma(year int, hour int, min int, obs Observer) []SunPathPoint {
+	var result []SunPathPoint
+	var it *sunIterator
+	var prev time.Time
+	for t := time.Date(year, 1, 1, hour, min, 0, 0, obs.Location); t.Year() == year; t = time.Date(year, 1, t.YearDay()+1, hour, min, 0, 0, obs.Location) {
+		if it == nil {
+			it = newSunIterator(t, obs.Latitude, obs.Longitude)
+		} else {
+			it.advance(float64(t.Sub(prev)) / float64(24*time.Hour))
+		}
+		prev = t
+
+		pos := it.position()
+		result = append(result, SunPathPoint{t, pos.Azimuth, pos.Altitude})
+	}
+
+	return result
+}
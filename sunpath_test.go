@@ -0,0 +1,89 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetSunPath(t *testing.T) {
+	obs := Observer{Latitude: 50.700078, Longitude: 2.891449, Location: time.FixedZone("Paris", 2*60*60)}
+	path := GetSunPath(time.Date(2020, 5, 17, 15, 5, 0, 0, obs.Location), obs, time.Minute)
+
+	if len(path) != 24*60+1 {
+		t.Fatalf("GetSunPath() returned %d points, want %d", len(path), 24*60+1)
+	}
+	if want := time.Date(2020, 5, 17, 0, 0, 0, 0, obs.Location); !path[0].Time.Equal(want) {
+		t.Errorf("GetSunPath() starts at %v, want %v", path[0].Time, want)
+	}
+	for _, p := range path {
+		want := GetPosition(p.Time, obs.Latitude, obs.Longitude)
+		if math.Abs(p.Azimuth-want.Azimuth) > 1e-7 || math.Abs(p.Altitude-want.Altitude) > 1e-7 {
+			t.Fatalf("GetSunPath() at %v = %v/%v, want %v", p.Time, p.Azimuth, p.Altitude, want)
+		}
+	}
+
+	if path := GetSunPath(time.Now(), obs, 0); path != nil {
+		t.Errorf("GetSunPath() with a zero step = %v, want nil", path)
+	}
+}
+
+func TestGetAnalemma(t *testing.T) {
+	obs := Observer{Latitude: 51.5, Longitude: -0.1, Location: time.UTC}
+
+	tests := []struct {
+		year int
+		want int
+	}{
+		{2019, 365},
+		{2020, 366},
+	}
+	for _, tt := range tests {
+		analemma := GetAnalemma(tt.year, 12, 0, obs)
+		if len(analemma) != tt.want {
+			t.Fatalf("GetAnalemma(%d) returned %d points, want %d", tt.year, len(analemma), tt.want)
+		}
+
+		highest, lowest := analemma[0], analemma[0]
+		for _, p := range analemma {
+			want := GetPosition(p.Time, obs.Latitude, obs.Longitude)
+			if math.Abs(p.Azimuth-want.Azimuth) > 1e-7 || math.Abs(p.Altitude-want.Altitude) > 1e-7 {
+				t.Fatalf("GetAnalemma() at %v = %v/%v, want %v", p.Time, p.Azimuth, p.Altitude, want)
+			}
+			if p.Time.Hour() != 12 || p.Time.Minute() != 0 {
+				t.Fatalf("GetAnalemma() sampled %v, want 12:00", p.Time)
+			}
+			if p.Altitude > highest.Altitude {
+				highest = p
+			}
+			if p.Altitude < lowest.Altitude {
+				lowest = p
+			}
+		}
+
+		// the extremes of the figure-eight are close to the solstices
+		if highest.Time.Month() != time.June {
+			t.Errorf("GetAnalemma(%d) highest point on %v", tt.year, highest.Time)
+		}
+		if lowest.Time.Month() != time.December {
+			t.Errorf("GetAnalemma(%d) lowest point on %v", tt.year, lowest.Time)
+		}
+	}
+}
+
+func BenchmarkGetSunPath(b *testing.B) {
+	obs := Observer{Latitude: 50.700078, Longitude: 2.891449, Location: time.UTC}
+	date := time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC)
+	b.Run("GetSunPath", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			GetSunPath(date, obs, time.Minute)
+		}
+	})
+	b.Run("GetPosition", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for t := date; !t.After(date.AddDate(0, 0, 1)); t = t.Add(time.Minute) {
+				GetPosition(t, obs.Latitude, obs.Longitude)
+			}
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package suncalc
+
+import (
+	"math"
+	"time"
+)
+
+// Surface is a flat tilted plane receiving the sun light, like a solar panel
+type Surface struct {
+	// Angle between the surface and the horizontal plane, in radians
+	Tilt float64
+
+	// Direction the surface is facing, in radians, measured from south to west like SunPosition.Azimuth
+	Azimuth float64
+
+	// Reflectance of the ground in front of the surface, about 0.2 for grass and 0.8 for fresh snow
+	Albedo float64
+}
+
+// PlaneOfArray irradiance received by a surface, in W/m²
+type PlaneOfArray struct {
+	AngleOfIncidence float64 // angle between the sun rays and the normal of the surface, in radians
+	CosineFactor     float64 // cosine of the angle of incidence, zero when the sun is behind the surface
+
+	Beam            float64 // direct irradiance
+	SkyDiffuse      float64 // diffuse irradiance from the sky
+	GroundReflected float64 // irradiance reflected by the ground
+	Global          float64 // sum of all components
+}
+
+// returns the angle between the sun rays and the normal of the surface, in radians
+func (s Surface) AngleOfIncidence(pos SunPosition) float64 {
+	cosAOI := math.Sin(pos.Altitude)*math.Cos(s.Tilt) + math.Cos(pos.Altitude)*math.Sin(s.Tilt)*math.Cos(pos.Azimuth-s.Azimuth)
+	return math.Acos(math.Max(-1, math.Min(1, cosAOI)))
+}
+
+// transposes the horizontal irradiance to the plane of the surface, with an isotropic sky model
+func (s Surface) Transpose(pos SunPosition, irr Irradiance) PlaneOfArray {
+	aoi := s.AngleOfIncidence(pos)
+	cosAOI := math.Max(math.Cos(aoi), 0)
+	if pos.Altitude <= 0 {
+		cosAOI = 0
+	}
+
+	result := PlaneOfArray{
+		AngleOfIncidence: aoi,
+		CosineFactor:     cosAOI,
+		Beam:             irr.DNI * cosAOI,
+		SkyDiffuse:       irr.DHI * (1 + math.Cos(s.Tilt)) / 2,
+		GroundReflected:  irr.GHI * s.Albedo * (1 - math.Cos(s.Tilt)) / 2,
+	}
+	result.Global = result.Beam + result.SkyDiffuse + result.GroundReflected
+	return result
+}
+
+// calculates the clear-sky irradiance received by the surface for a given date and observer
+func GetPlaneOfArrayIrradiance(date time.Time, obs Observer, surface Surface, model ClearSkyModel) PlaneOfArray {
+	pos := GetPosition(date, obs.Latitude, obs.Longitude)
+	return surface.Transpose(pos, clearSkyIrradiance(date, pos, model))
+}
+
+// step of the numerical integration of the irradiance
+const insolationStep = 5 * time.Minute
+
+// calculates the clear-sky energy received by the surface during the observer's local day, in Wh/m²
+func GetDailyInsolation(date time.Time, obs Observer, surface Surface, model ClearSkyModel) float64 {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, obs.Location)
+	return insolation(start, start.AddDate(0, 0, 1), obs, surface, model)
+}
+
+// calculates the clear-sky energy received by the surface during the year, in Wh/m²
+func GetAnnualInsolation(year int, obs Observer, surface Surface, model ClearSkyModel) float64 {
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, obs.Location)
+	return insolation(start, start.AddDate(1, 0, 0), obs, surface, model)
+}
+
+// integrates the plane of array irradiance from start to end with the trapezoidal rule
+func insolation(start time.Time, end time.Time, obs Observer, surface Surface, model ClearSkyModel) float64 {
+	var energy float64
+	prev := GetPlaneOfArrayIrradiance(start, obs, surface, model).Global
+	for t := start; t.Before(end); {
+		next := t.Add(insolationStep)
+		if next.After(end) {
+			next = end
+		}
+		current := GetPlaneOfArrayIrradiance(next, obs, surface, model).Global
+		energy += (prev + current) / 2 * next.Sub(t).Hours()
+		t, prev = next, current
+	}
+	return energy
+}
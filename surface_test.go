@@ -0,0 +1,71 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSurfaceAngleOfIncidence(t *testing.T) {
+	pos := SunPosition{Azimuth: 30 * rad, Altitude: 40 * rad}
+
+	tests := []struct {
+		name    string
+		surface Surface
+		want    float64
+	}{
+		{"horizontal", Surface{}, 50 * rad},
+		{"facing the sun", Surface{Tilt: 50 * rad, Azimuth: 30 * rad}, 0},
+		{"vertical, facing the sun", Surface{Tilt: 90 * rad, Azimuth: 30 * rad}, 40 * rad},
+		{"vertical, sun behind", Surface{Tilt: 90 * rad, Azimuth: -150 * rad}, 140 * rad},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.surface.AngleOfIncidence(pos); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("AngleOfIncidence() = %v, want %v", got/rad, tt.want/rad)
+			}
+		})
+	}
+}
+
+func TestSurfaceTranspose(t *testing.T) {
+	pos := SunPosition{Azimuth: 0, Altitude: 60 * rad}
+	irr := Irradiance{GHI: 900, DNI: 900, DHI: 900 - 900*math.Sin(60*rad)}
+
+	horizontal := Surface{Albedo: 0.2}.Transpose(pos, irr)
+	if math.Abs(horizontal.Global-irr.GHI) > 1e-9 {
+		t.Errorf("Transpose() horizontal = %+v, want a global irradiance of %v", horizontal, irr.GHI)
+	}
+
+	behind := Surface{Tilt: 90 * rad, Azimuth: math.Pi, Albedo: 0.2}.Transpose(pos, irr)
+	if behind.Beam != 0 || behind.CosineFactor != 0 {
+		t.Errorf("Transpose() sun behind = %+v, want no beam", behind)
+	}
+	if want := irr.DHI/2 + irr.GHI*0.2/2; math.Abs(behind.Global-want) > 1e-9 {
+		t.Errorf("Transpose() sun behind = %+v, want a global irradiance of %v", behind, want)
+	}
+}
+
+func TestGetInsolation(t *testing.T) {
+	obs := Observer{Latitude: 45, Longitude: 0, Location: time.UTC}
+	model := Ineichen{LinkeTurbidity: 3}
+	horizontal := Surface{Albedo: 0.2}
+	south := Surface{Tilt: 45 * rad, Albedo: 0.2}
+	north := Surface{Tilt: 90 * rad, Azimuth: math.Pi, Albedo: 0.2}
+
+	winter := time.Date(2020, 12, 21, 0, 0, 0, 0, time.UTC)
+	if h, s := GetDailyInsolation(winter, obs, horizontal, model), GetDailyInsolation(winter, obs, south, model); s < 1.5*h {
+		t.Errorf("GetDailyInsolation() in winter = %v for a south facing surface, %v for a horizontal one", s, h)
+	}
+	if h, n := GetDailyInsolation(winter, obs, horizontal, model), GetDailyInsolation(winter, obs, north, model); n > h/3 {
+		t.Errorf("GetDailyInsolation() in winter = %v for a north facing surface, %v for a horizontal one", n, h)
+	}
+
+	annual := GetAnnualInsolation(2020, obs, south, model)
+	if annual < 1800e3 || annual > 2600e3 {
+		t.Errorf("GetAnnualInsolation() = %v kWh/m², want about 2000", annual/1000)
+	}
+	if h := GetAnnualInsolation(2020, obs, horizontal, model); h > annual {
+		t.Errorf("GetAnnualInsolation() = %v for a horizontal surface, more than %v tilted", h, annual)
+	}
+}
@@ -0,0 +1,232 @@
+package suncalc
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+type Projection int
+
+const (
+	Stereographic Projection = iota // polar view of the sky, zenith at the center and horizon on the outer circle
+	Cylindrical                     // azimuth on the horizontal axis, altitude on the vertical one
+)
+
+type SunPathDiagram struct {
+	Projection Projection
+
+	// Year of the monthly day arcs and of the hour lines
+	Year int
+
+	// Width of the image in pixels, a stereographic diagram is square,
+	// a cylindrical one is half as high as wide
+	Size int
+
+	// Draws the observer's horizon profile
+	ShowHorizon bool
+
+	// Draws the path of the moon during that local day, if not zero
+	MoonDate time.Time
+}
+
+const svgMargin = 30
+const svgStep = 10 * time.Minute
+
+// renders a sun path diagram for the observer: the day arcs of the 21st of every month,
+// the hour lines (analemmas of the observer's clock hours), and optionally the horizon
+// profile and the path of the moon
+func RenderSunPathSVG(w io.Writer, obs Observer, diagram SunPathDiagram) error {
+	if diagram.Size <= 2*svgMargin {
+		return fmt.Errorf("suncalc: diagram size %d is too small", diagram.Size)
+	}
+	c := newSvgCanvas(w, diagram)
+
+	c.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`+"\n",
+		c.width, c.height, c.width, c.height)
+	c.printf(`<rect width="%d" height="%d" fill="white"/>`+"\n", c.width, c.height)
+	c.grid()
+
+	if diagram.ShowHorizon && len(obs.Horizon) > 0 {
+		c.horizon(obs.Horizon)
+	}
+
+	for h := 0; h < 24; h++ {
+		analemma := GetAnalemma(diagram.Year, h, 0, obs)
+		c.path(analemma, `fill="none" stroke="#888888" stroke-width="1"`)
+		if top, ok := highest(analemma); ok {
+			x, y := c.project(top.Azimuth, top.Altitude)
+			c.printf(`<text x="%.1f" y="%.1f" fill="#555555" text-anchor="middle">%d</text>`+"\n", x, y-4, h)
+		}
+	}
+
+	for m := time.January; m <= time.December; m++ {
+		arc := GetSunPath(time.Date(diagram.Year, m, 21, 0, 0, 0, 0, obs.Location), obs, svgStep)
+		c.path(arc, `fill="none" stroke="#f5a623" stroke-width="1.5"`)
+		if top, ok := highest(arc); ok && (m <= time.June || m == time.December) {
+			x, y := c.project(top.Azimuth, top.Altitude)
+			c.printf(`<text x="%.1f" y="%.1f" fill="#c47d00">%s 21</text>`+"\n", x+4, y+12, m.String()[:3])
+		}
+	}
+
+	if !diagram.MoonDate.IsZero() {
+		start := time.Date(diagram.MoonDate.Year(), diagram.MoonDate.Month(), diagram.MoonDate.Day(), 0, 0, 0, 0, obs.Location)
+		var moon []SunPathPoint
+		for t := start; !t.After(start.AddDate(0, 0, 1)); t = t.Add(svgStep) {
+			pos := GetMoonPosition(t, obs.Latitude, obs.Longitude)
+			moon = append(moon, SunPathPoint{t, pos.Azimuth, pos.Altitude})
+		}
+		c.path(moon, `fill="none" stroke="#4a6fa5" stroke-width="1.5" stroke-dasharray="4 3"`)
+	}
+
+	c.printf("</svg>\n")
+	return c.err
+}
+
+type svgCanvas struct {
+	w             io.Writer
+	err           error
+	projection    Projection
+	width, height int
+}
+
+func newSvgCanvas(w io.Writer, diagram SunPathDiagram) *svgCanvas {
+	c := &svgCanvas{w: w, projection: diagram.Projection, width: diagram.Size, height: diagram.Size}
+	if c.projection == Cylindrical {
+		c.height = diagram.Size / 2
+	}
+	return c
+}
+
+// writes to the underlying writer, keeping the first error
+func (c *svgCanvas) printf(format string, a ...interface{}) {
+	if c.err == nil {
+		_, c.err = fmt.Fprintf(c.w, format, a...)
+	}
+}
+
+// returns the bearing of the azimuth: clockwise from north, in the range [0, 2*PI)
+func bearing(azimuth float64) float64 {
+	return azimuthSpan(math.Pi, azimuth)
+}
+
+// returns the position on the image of the given sky direction
+func (c *svgCanvas) project(azimuth float64, altitude float64) (float64, float64) {
+	return c.projectBearing(bearing(azimuth), altitude)
+}
+
+// same as project, with a bearing in the range [0, 2*PI]
+func (c *svgCanvas) projectBearing(b float64, altitude float64) (float64, float64) {
+	if c.projection == Cylindrical {
+		w := float64(c.width - 2*svgMargin)
+		h := float64(c.height - 2*svgMargin)
+		return svgMargin + b/(2*math.Pi)*w, svgMargin + (1-altitude/(math.Pi/2))*h
+	}
+
+	center := float64(c.width) / 2
+	r := (center - svgMargin) * math.Tan((math.Pi/2-altitude)/2)
+	return center + r*math.Sin(b), center - r*math.Cos(b)
+}
+
+// draws the part of the points above the horizon as polylines
+func (c *svgCanvas) path(points []SunPathPoint, style string) {
+	var segment []SunPathPoint
+	flush := func() {
+		if len(segment) > 1 {
+			c.printf(`<polyline %s points="`, style)
+			for i, p := range segment {
+				x, y := c.project(p.Azimuth, p.Altitude)
+				if i > 0 {
+					c.printf(" ")
+				}
+				c.printf("%.1f,%.1f", x, y)
+			}
+			c.printf(`"/>` + "\n")
+		}
+		segment = segment[:0]
+	}
+
+	for i, p := range points {
+		if p.Altitude < 0 {
+			flush()
+			continue
+		}
+		// a cylindrical diagram is cut at north
+		if c.projection == Cylindrical && i > 0 && math.Abs(bearing(p.Azimuth)-bearing(points[i-1].Azimuth)) > math.Pi {
+			flush()
+		}
+		segment = append(segment, p)
+	}
+	flush()
+}
+
+// draws the altitude circles every 10 degrees and the azimuth lines every 30 degrees
+func (c *svgCanvas) grid() {
+	const style = `fill="none" stroke="#dddddd" stroke-width="1"`
+	for alt := 0; alt < 90; alt += 10 {
+		x, y := c.projectBearing(0, float64(alt)*rad)
+		if c.projection == Cylindrical {
+			c.printf(`<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" %s/>`+"\n", svgMargin, y, c.width-svgMargin, y, style)
+		} else {
+			center := float64(c.width) / 2
+			c.printf(`<circle cx="%.1f" cy="%.1f" r="%.1f" %s/>`+"\n", center, center, center-y, style)
+		}
+		c.printf(`<text x="%.1f" y="%.1f" fill="#aaaaaa">%d°</text>`+"\n", x+2, y-2, alt)
+	}
+
+	names := map[int]string{0: "N", 90: "E", 180: "S", 270: "W", 360: "N"}
+	last := 330
+	if c.projection == Cylindrical {
+		last = 360
+	}
+	for b := 0; b <= last; b += 30 {
+		x1, y1 := c.projectBearing(float64(b)*rad, 0)
+		x2, y2 := c.projectBearing(float64(b)*rad, math.Pi/2)
+		c.printf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" %s/>`+"\n", x1, y1, x2, y2, style)
+
+		label, ok := names[b]
+		if !ok {
+			label = fmt.Sprintf("%d°", b)
+		}
+		if c.projection == Cylindrical {
+			c.printf(`<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`+"\n", x1, y1+15, label)
+		} else {
+			x, y := c.projectBearing(float64(b)*rad, -8*rad)
+			c.printf(`<text x="%.1f" y="%.1f" text-anchor="middle" dominant-baseline="middle">%s</text>`+"\n", x, y, label)
+		}
+	}
+}
+
+// fills the sky hidden by the horizon profile
+func (c *svgCanvas) horizon(profile HorizonProfile) {
+	c.printf(`<polygon fill="#999999" fill-opacity="0.5" stroke="#666666" points="`)
+	for b := 0; b <= 360; b += 2 {
+		x, y := c.projectBearing(float64(b)*rad, profile.Altitude(float64(b)*rad-math.Pi))
+		c.printf("%.1f,%.1f ", x, y)
+	}
+	// close along the horizon, the outer edge of the diagram
+	if c.projection == Cylindrical {
+		x1, y1 := c.projectBearing(2*math.Pi, 0)
+		x2, y2 := c.projectBearing(0, 0)
+		c.printf("%.1f,%.1f %.1f,%.1f", x1, y1, x2, y2)
+	} else {
+		for b := 360; b >= 0; b -= 2 {
+			x, y := c.projectBearing(float64(b)*rad, 0)
+			c.printf(" %.1f,%.1f", x, y)
+		}
+	}
+	c.printf(`"/>` + "\n")
+}
+
+// returns the highest point above the horizon
+func highest(points []SunPathPoint) (SunPathPoint, bool) {
+	var top SunPathPoint
+	found := false
+	for _, p := range points {
+		if p.Altitude >= 0 && (!found || p.Altitude > top.Altitude) {
+			top, found = p, true
+		}
+	}
+	return top, found
+}
@@ -0,0 +1,59 @@
+package suncalc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderSunPathSVG(t *testing.T) {
+	obs := Observer{Latitude: 48.85, Longitude: 2.35, Location: time.FixedZone("Paris", 60*60)}
+	obs.Horizon = NewHorizonProfile([]HorizonPoint{{-90 * rad, 10 * rad}, {90 * rad, 3 * rad}})
+
+	tests := []struct {
+		name    string
+		diagram SunPathDiagram
+		moon    bool
+	}{
+		{"stereographic", SunPathDiagram{Projection: Stereographic, Year: 2020, Size: 600}, false},
+		{"cylindrical", SunPathDiagram{Projection: Cylindrical, Year: 2020, Size: 800, ShowHorizon: true}, false},
+		{"moon", SunPathDiagram{Year: 2020, Size: 600, ShowHorizon: true, MoonDate: time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := RenderSunPathSVG(&buf, obs, tt.diagram); err != nil {
+				t.Fatalf("RenderSunPathSVG() error = %v", err)
+			}
+
+			svg := buf.String()
+			decoder := xml.NewDecoder(strings.NewReader(svg))
+			for {
+				_, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("RenderSunPathSVG() produced invalid XML: %v", err)
+				}
+			}
+
+			if got := strings.Count(svg, `stroke="#f5a623"`); got < 12 {
+				t.Errorf("RenderSunPathSVG() drew %d day arcs, want at least 12", got)
+			}
+			if got := strings.Contains(svg, "<polygon"); got != tt.diagram.ShowHorizon {
+				t.Errorf("RenderSunPathSVG() horizon drawn = %v, want %v", got, tt.diagram.ShowHorizon)
+			}
+			if got := strings.Contains(svg, `stroke="#4a6fa5"`); got != tt.moon {
+				t.Errorf("RenderSunPathSVG() moon drawn = %v, want %v", got, tt.moon)
+			}
+		})
+	}
+
+	if err := RenderSunPathSVG(io.Discard, obs, SunPathDiagram{Year: 2020, Size: 10}); err == nil {
+		t.Errorf("RenderSunPathSVG() expected an error on a tiny diagram")
+	}
+}
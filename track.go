@@ -0,0 +1,60 @@
+package suncalc
+
+import (
+	"sort"
+	"time"
+)
+
+// MoonTimeName names the moon events, which are not sun times and cannot be used where a DayTimeName is expected
+type MoonTimeName string
+
+const (
+	Moonrise MoonTimeName = "moonrise" // top edge of the moon appears on the horizon
+	Moonset  MoonTimeName = "moonset"  // moon disappears below the horizon
+)
+
+// TrackEvent is a sun time, or a moon rise or set, experienced along a track
+type TrackEvent struct {
+	Name     DayTimeName  // sun time, empty for a moon event
+	Moon     MoonTimeName // moon event, empty for a sun time
+	Time     time.Time
+	Position TrackPoint
+}
+
+// calculates the sun times (see DayTimeNames) and the moon rises and sets experienced along a moving track,
+// from its first to its last point, the horizon being lowered by the height of the vehicle
+func GetTrackEvents(track Track) []TrackEvent {
+	if len(track) == 0 {
+		return nil
+	}
+	start, end := track[0].Time, track[len(track)-1].Time
+
+	var result []TrackEvent
+	for _, conf := range times {
+		angle := conf.angle
+		for _, c := range findCrossings(start, end, horizonStep, func(t time.Time) float64 {
+			p := track.At(t)
+			return GetPosition(t, p.Latitude, p.Longitude).Altitude - (angle+observerAngle(p.Height))*rad
+		}) {
+			name := conf.eveningName
+			if c.rising {
+				name = conf.morningName
+			}
+			result = append(result, TrackEvent{Name: name, Time: c.time, Position: track.At(c.time)})
+		}
+	}
+	for _, c := range findCrossings(start, end, horizonStep, func(t time.Time) float64 {
+		p := track.At(t)
+		_, _, h := topocentric(t, p.Latitude, p.Longitude, Moon)
+		return h + Moon.Radius(t) + horizonRefraction - observerAngle(p.Height)*rad // as GetBodyTimes
+	}) {
+		name := Moonset
+		if c.rising {
+			name = Moonrise
+		}
+		result = append(result, TrackEvent{Moon: name, Time: c.time, Position: track.At(c.time)})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Time.Before(result[j].Time) })
+	return result
+}
@@ -0,0 +1,95 @@
+package suncalc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetTrackEvents(t *testing.T) {
+	start := time.Date(2021, 3, 21, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	// GetTimes finds the upper edge of the sun, the track its center 0.833° lower, within a second or so
+	const tolerance = 2 * time.Second
+
+	tests := []struct {
+		name  string
+		track Track
+		sun   int
+	}{
+		{"fixed", Track{{Time: start, Latitude: 51.5, Longitude: -0.1}, {Time: end, Latitude: 51.5, Longitude: -0.1}}, 12},
+		// following the sun, the night is not reached before the end of the track
+		{"flying west", Track{{Time: start, Latitude: 51.5, Longitude: 60}, {Time: end, Latitude: 51.5, Longitude: -60}}, 11},
+		{"sailing north", Track{{Time: start, Latitude: 40, Longitude: -30}, {Time: end, Latitude: 45, Longitude: -30}}, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetTrackEvents(tt.track)
+
+			sun := 0
+			for i, event := range got {
+				if i > 0 && event.Time.Before(got[i-1].Time) {
+					t.Errorf("GetTrackEvents() %v before %v", event.Time, got[i-1].Time)
+				}
+				if event.Moon != "" {
+					if event.Name != "" || (event.Moon != Moonrise && event.Moon != Moonset) {
+						t.Errorf("GetTrackEvents() moon event %+v", event)
+					}
+					continue
+				}
+				sun++
+
+				// a fixed observer at the position of the event sees it at the same time
+				p := event.Position
+				want := GetTimesWithObserver(event.Time, Observer{p.Latitude, p.Longitude, 0, time.UTC, nil})[event.Name].Value
+				if d := event.Time.Sub(want); d > tolerance || d < -tolerance {
+					t.Errorf("GetTrackEvents() %v at %v, want %v", event.Name, event.Time, want)
+				}
+			}
+			if sun != tt.sun {
+				t.Errorf("GetTrackEvents() found %d sun events, want %d", sun, tt.sun)
+			}
+		})
+	}
+}
+
+func TestGetTrackEventsMoon(t *testing.T) {
+	start := time.Date(2021, 3, 21, 0, 0, 0, 0, time.UTC)
+	obs := Observer{48.85, 2.35, 0, time.UTC, nil}
+	track := Track{{Time: start, Latitude: obs.Latitude, Longitude: obs.Longitude}, {Time: start.Add(24 * time.Hour), Latitude: obs.Latitude, Longitude: obs.Longitude}}
+	want := GetMoonHorizonTimes(start, obs)
+
+	var rise, set time.Time
+	for _, event := range GetTrackEvents(track) {
+		switch event.Moon {
+		case Moonrise:
+			rise = event.Time
+		case Moonset:
+			set = event.Time
+		}
+	}
+	if !rise.Equal(want.Rise) || !set.Equal(want.Set) {
+		t.Errorf("GetTrackEvents() moon = %v, %v, want %v, %v", rise, set, want.Rise, want.Set)
+	}
+}
+
+func TestGetTrackEventsHeight(t *testing.T) {
+	start := time.Date(2021, 3, 21, 12, 0, 0, 0, time.UTC)
+	track := func(height float64) Track {
+		return Track{{Time: start, Latitude: 51.5, Longitude: -0.1, Height: height}, {Time: start.Add(12 * time.Hour), Latitude: 51.5, Longitude: -0.1, Height: height}}
+	}
+
+	sunset := func(events []TrackEvent) time.Time {
+		for _, event := range events {
+			if event.Name == Sunset {
+				return event.Time
+			}
+		}
+		return time.Time{}
+	}
+
+	// the sun sets later seen from higher
+	if low, high := sunset(GetTrackEvents(track(0))), sunset(GetTrackEvents(track(10000))); !high.After(low.Add(10 * time.Minute)) {
+		t.Errorf("GetTrackEvents() sunset at 10000 m = %v, want well after %v", high, low)
+	}
+}
@@ -0,0 +1,152 @@
+package suncalc
+
+import (
+	"math"
+)
+
+// SingleAxisTracker rotates its surface around one axis to follow the sun,
+// all angles are in radians
+type SingleAxisTracker struct {
+	// Angle between the axis and the horizontal plane
+	AxisTilt float64
+
+	// Direction the axis is pointing to, measured from south to west: 0 for a north-south axis
+	AxisAzimuth float64
+
+	// Maximum rotation of the surface on both sides of its rest position, 0 means no limit
+	MaxAngle float64
+
+	// Width of the surface divided by the distance between two rows, enables
+	// backtracking, to avoid the rows shading each other, when set
+	GroundCoverageRatio float64
+
+	// Rotation of the surface when stowed
+	StowAngle float64
+
+	// Altitude of the sun below which the tracker is stowed
+	MinAltitude float64
+}
+
+// DualAxisTracker points its surface towards the sun, all angles are in radians
+type DualAxisTracker struct {
+	// Maximum tilt of the surface, 0 means no limit
+	MaxTilt float64
+
+	// Tilt of the surface when stowed, facing south
+	StowTilt float64
+
+	// Altitude of the sun below which the tracker is stowed
+	MinAltitude float64
+}
+
+type TrackerSetpoint struct {
+	// Rotation around the axis of a single-axis tracker, positive clockwise when looking along
+	// the axis: towards west for an axis pointing south. Always 0 for a dual-axis tracker.
+	Rotation float64
+
+	// Resulting orientation of the surface
+	Surface Surface
+
+	// Angle between the sun rays and the normal of the surface
+	AngleOfIncidence float64
+
+	// The rotation is reduced to avoid the shade of the neighbouring rows
+	Backtracking bool
+
+	// The tracker is in its stow position
+	Stowed bool
+}
+
+// returns the sun direction as a unit vector (east, north, up)
+func sunVector(pos SunPosition) (float64, float64, float64) {
+	b := pos.Azimuth + math.Pi
+	return math.Cos(pos.Altitude) * math.Sin(b), math.Cos(pos.Altitude) * math.Cos(b), math.Sin(pos.Altitude)
+}
+
+// returns the surface whose normal is the given unit vector (east, north, up)
+func surfaceFromNormal(x float64, y float64, z float64) Surface {
+	return Surface{
+		Tilt:    math.Acos(math.Max(-1, math.Min(1, z))),
+		Azimuth: normalizeAzimuth(math.Atan2(x, y) - math.Pi),
+	}
+}
+
+// calculates the setpoint of the tracker for the given sun position,
+// based on the NREL technical report "Rotation Angle for the Optimum Tracking
+// of One-Axis Trackers" (W. F. Marion and A. P. Dobos, 2013) and its backtracking
+// extension (K. S. Anderson and M. A. Mikofski, 2020) for a flat ground
+func (t SingleAxisTracker) Setpoint(pos SunPosition) TrackerSetpoint {
+	if pos.Altitude < t.MinAltitude {
+		return t.Stow(pos)
+	}
+
+	x, y, z := sunVector(pos)
+	sinAA, cosAA := math.Sincos(t.AxisAzimuth + math.Pi)
+	sinAT, cosAT := math.Sincos(t.AxisTilt)
+
+	// sun vector in the frame of the tracker, the axis being along the second coordinate
+	xp := x*cosAA - y*sinAA
+	zp := x*sinAT*sinAA + y*sinAT*cosAA + z*cosAT
+
+	result := TrackerSetpoint{Rotation: math.Atan2(xp, zp)}
+
+	if t.GroundCoverageRatio > 0 {
+		// the shade of a row reaches the next one when cos(rotation) < gcr
+		if c := math.Abs(math.Cos(result.Rotation) / t.GroundCoverageRatio); c < 1 {
+			sign := 1.0
+			if result.Rotation < 0 {
+				sign = -1
+			}
+			result.Rotation -= sign * math.Acos(c)
+			result.Backtracking = true
+		}
+	}
+
+	if t.MaxAngle > 0 {
+		result.Rotation = math.Max(-t.MaxAngle, math.Min(t.MaxAngle, result.Rotation))
+	}
+
+	t.orient(&result, pos)
+	return result
+}
+
+// returns the stow setpoint of the tracker, e.g. for high wind conditions
+func (t SingleAxisTracker) Stow(pos SunPosition) TrackerSetpoint {
+	result := TrackerSetpoint{Rotation: t.StowAngle, Stowed: true}
+	t.orient(&result, pos)
+	return result
+}
+
+// sets the surface and angle of incidence of the setpoint from its rotation
+func (t SingleAxisTracker) orient(setpoint *TrackerSetpoint, pos SunPosition) {
+	sinAA, cosAA := math.Sincos(t.AxisAzimuth + math.Pi)
+	sinAT, cosAT := math.Sincos(t.AxisTilt)
+	sinR, cosR := math.Sincos(setpoint.Rotation)
+
+	// normal of the surface back from the frame of the tracker
+	setpoint.Surface = surfaceFromNormal(
+		sinR*cosAA+cosR*sinAT*sinAA,
+		-sinR*sinAA+cosR*sinAT*cosAA,
+		cosR*cosAT,
+	)
+	setpoint.AngleOfIncidence = setpoint.Surface.AngleOfIncidence(pos)
+}
+
+// calculates the setpoint of the tracker for the given sun position
+func (t DualAxisTracker) Setpoint(pos SunPosition) TrackerSetpoint {
+	if pos.Altitude < t.MinAltitude {
+		return t.Stow(pos)
+	}
+
+	surface := Surface{Tilt: math.Pi/2 - pos.Altitude, Azimuth: pos.Azimuth}
+	if t.MaxTilt > 0 && surface.Tilt > t.MaxTilt {
+		surface.Tilt = t.MaxTilt
+	}
+	return TrackerSetpoint{Surface: surface, AngleOfIncidence: surface.AngleOfIncidence(pos)}
+}
+
+// returns the stow setpoint of the tracker, e.g. for high wind conditions
+func (t DualAxisTracker) Stow(pos SunPosition) TrackerSetpoint {
+	surface := Surface{Tilt: t.StowTilt}
+	return TrackerSetpoint{Surface: surface, AngleOfIncidence: surface.AngleOfIncidence(pos), Stowed: true}
+}
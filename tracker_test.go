@@ -0,0 +1,117 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSingleAxisTrackerSetpoint(t *testing.T) {
+	east30 := SunPosition{Azimuth: -90 * rad, Altitude: 30 * rad}
+	east10 := SunPosition{Azimuth: -90 * rad, Altitude: 10 * rad}
+	west10 := SunPosition{Azimuth: 90 * rad, Altitude: 10 * rad}
+
+	tests := []struct {
+		name    string
+		tracker SingleAxisTracker
+		pos     SunPosition
+		want    TrackerSetpoint
+	}{
+		{
+			"facing east",
+			SingleAxisTracker{},
+			east30,
+			TrackerSetpoint{Rotation: -60 * rad, Surface: Surface{Tilt: 60 * rad, Azimuth: -90 * rad}},
+		},
+		{
+			"no shade between rows",
+			SingleAxisTracker{GroundCoverageRatio: 0.4},
+			east30,
+			TrackerSetpoint{Rotation: -60 * rad, Surface: Surface{Tilt: 60 * rad, Azimuth: -90 * rad}},
+		},
+		{
+			"backtracking in the morning",
+			SingleAxisTracker{GroundCoverageRatio: 0.4},
+			east10,
+			TrackerSetpoint{Rotation: -15.72935 * rad, Surface: Surface{Tilt: 15.72935 * rad, Azimuth: -90 * rad}, AngleOfIncidence: 64.27065 * rad, Backtracking: true},
+		},
+		{
+			"backtracking in the evening",
+			SingleAxisTracker{GroundCoverageRatio: 0.4},
+			west10,
+			TrackerSetpoint{Rotation: 15.72935 * rad, Surface: Surface{Tilt: 15.72935 * rad, Azimuth: 90 * rad}, AngleOfIncidence: 64.27065 * rad, Backtracking: true},
+		},
+		{
+			"rotation limit",
+			SingleAxisTracker{MaxAngle: 45 * rad},
+			east30,
+			TrackerSetpoint{Rotation: -45 * rad, Surface: Surface{Tilt: 45 * rad, Azimuth: -90 * rad}, AngleOfIncidence: 15 * rad},
+		},
+		{
+			"east-west axis",
+			SingleAxisTracker{AxisAzimuth: -90 * rad},
+			SunPosition{Azimuth: 0, Altitude: 60 * rad},
+			TrackerSetpoint{Rotation: 30 * rad, Surface: Surface{Tilt: 30 * rad, Azimuth: 0}},
+		},
+		{
+			"stowed at night",
+			SingleAxisTracker{StowAngle: 0, MinAltitude: 0},
+			SunPosition{Azimuth: 0, Altitude: -10 * rad},
+			TrackerSetpoint{Surface: Surface{}, AngleOfIncidence: 100 * rad, Stowed: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.tracker.Setpoint(tt.pos)
+			if math.Abs(got.Rotation-tt.want.Rotation) > 1e-5 ||
+				math.Abs(got.Surface.Tilt-tt.want.Surface.Tilt) > 1e-5 ||
+				(got.Surface.Tilt > 1e-6 && math.Abs(got.Surface.Azimuth-tt.want.Surface.Azimuth) > 1e-6) ||
+				math.Abs(got.AngleOfIncidence-tt.want.AngleOfIncidence) > 1e-5 ||
+				got.Backtracking != tt.want.Backtracking || got.Stowed != tt.want.Stowed {
+				t.Errorf("Setpoint() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSingleAxisTrackerDay(t *testing.T) {
+	tracker := SingleAxisTracker{MaxAngle: 60 * rad, GroundCoverageRatio: 0.35}
+	obs := Observer{Latitude: 35, Longitude: -110, Location: time.FixedZone("Arizona", -7*60*60)}
+
+	// the rotation goes from east to west without ever exceeding its limit
+	prev := -math.Pi
+	for _, p := range GetSunPath(time.Date(2020, 6, 21, 0, 0, 0, 0, obs.Location), obs, 10*time.Minute) {
+		if p.Altitude < 2*rad {
+			continue
+		}
+		setpoint := tracker.Setpoint(SunPosition{p.Azimuth, p.Altitude})
+		if math.Abs(setpoint.Rotation) > tracker.MaxAngle+1e-9 {
+			t.Fatalf("Setpoint() at %v = %v, exceeds the limit", p.Time, setpoint.Rotation/rad)
+		}
+		if !setpoint.Backtracking && setpoint.Rotation < prev-1e-9 {
+			t.Fatalf("Setpoint() at %v = %v, goes back to %v", p.Time, setpoint.Rotation/rad, prev/rad)
+		}
+		if !setpoint.Backtracking {
+			prev = setpoint.Rotation
+		}
+	}
+}
+
+func TestDualAxisTrackerSetpoint(t *testing.T) {
+	pos := SunPosition{Azimuth: 20 * rad, Altitude: 30 * rad}
+
+	got := DualAxisTracker{}.Setpoint(pos)
+	if math.Abs(got.AngleOfIncidence) > 1e-6 || math.Abs(got.Surface.Tilt-60*rad) > 1e-9 || got.Surface.Azimuth != pos.Azimuth {
+		t.Errorf("Setpoint() = %+v, want the surface facing the sun", got)
+	}
+
+	got = DualAxisTracker{MaxTilt: 50 * rad}.Setpoint(pos)
+	if math.Abs(got.AngleOfIncidence-10*rad) > 1e-6 || math.Abs(got.Surface.Tilt-50*rad) > 1e-9 {
+		t.Errorf("Setpoint() = %+v, want the tilt limited to 50", got)
+	}
+
+	got = DualAxisTracker{StowTilt: 10 * rad, MinAltitude: 35 * rad}.Setpoint(pos)
+	if !got.Stowed || math.Abs(got.Surface.Tilt-10*rad) > 1e-9 || got.Surface.Azimuth != 0 {
+		t.Errorf("Setpoint() = %+v, want the tracker stowed", got)
+	}
+}
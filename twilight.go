@@ -0,0 +1,78 @@
+package suncalc
+
+import (
+	"time"
+)
+
+// LightPhase is the phase of the day given by the altitude of the sun. The phases partition the altitudes
+// without overlap, so the civil twilight, from -6° to the sunrise and the sunset, is split in two phases by the start
+// of the golden hour at -4°, and the golden hour, from -4° to 6°, in two phases by the sunrise and the sunset
+type LightPhase string
+
+const (
+	NightPhase           LightPhase = "night"                // sun below -18°
+	AstronomicalPhase    LightPhase = "astronomicalTwilight" // from -18° to -12°
+	NauticalPhase        LightPhase = "nauticalTwilight"     // from -12° to -6°
+	CivilBlueHourPhase   LightPhase = "civilBlueHour"        // civil twilight during the blue hour, from -6° to -4°
+	CivilGoldenHourPhase LightPhase = "civilGoldenHour"      // civil twilight during the golden hour, from -4° to the sunrise and the sunset, -0.833°
+	DayGoldenHourPhase   LightPhase = "dayGoldenHour"        // golden hour with the sun up, from the sunrise and the sunset to 6°
+	DayPhase             LightPhase = "day"                  // sun above 6°
+)
+
+// the phases in the order of the altitudes of the sun, and the altitudes between them in degrees
+var (
+	lightPhases     = []LightPhase{NightPhase, AstronomicalPhase, NauticalPhase, CivilBlueHourPhase, CivilGoldenHourPhase, DayGoldenHourPhase, DayPhase}
+	lightPhaseEdges = []float64{-18, -12, -6, -4, -0.833, 6}
+)
+
+// LightPeriod is an interval of time during which the phase of the day does not change
+type LightPeriod struct {
+	Phase LightPhase
+	Interval
+}
+
+// calculates the timeline of the phases of the day from start to end, the altitudes of the sun being corrected
+// for the observer's height like the ones of GetTimesWithObserver. The periods follow each other without gap,
+// the first one starting at start and the last one ending at end, a single period covering a polar day or night
+func GetTwilightTimeline(start time.Time, end time.Time, obs Observer) []LightPeriod {
+	dh := observerAngle(obs.Height)
+	above := func(t time.Time, edge int) bool {
+		return GetPosition(t, obs.Latitude, obs.Longitude).Altitude > (lightPhaseEdges[edge]+dh)*rad
+	}
+	phase := func(t time.Time) int {
+		p := 0
+		for p < len(lightPhaseEdges) && above(t, p) {
+			p++
+		}
+		return p
+	}
+
+	t0, p0 := start, phase(start)
+	result := []LightPeriod{{lightPhases[p0], Interval{Start: start}}}
+	next := func(edge time.Time, p int) {
+		result[len(result)-1].End = edge
+		result = append(result, LightPeriod{lightPhases[p], Interval{Start: edge}})
+	}
+	for t0.Before(end) {
+		t1 := t0.Add(horizonStep)
+		if t1.After(end) {
+			t1 = end
+		}
+		p1 := phase(t1)
+
+		// crosses all the edges between the two phases, usually one
+		for ; p0 < p1; p0++ {
+			edge := p0
+			next(bisectCondition(t0, t1, false, func(t time.Time) bool { return above(t, edge) }), p0+1)
+		}
+		for ; p0 > p1; p0-- {
+			edge := p0 - 1
+			next(bisectCondition(t0, t1, true, func(t time.Time) bool { return above(t, edge) }), p0-1)
+		}
+
+		t0 = t1
+	}
+	result[len(result)-1].End = end
+
+	return result
+}
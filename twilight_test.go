@@ -0,0 +1,87 @@
+package suncalc
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetTwilightTimeline(t *testing.T) {
+	obs := Observer{Latitude: 48.85, Longitude: 2.35, Height: 50, Location: time.UTC}
+	start := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	times := GetTimesWithObserver(start, obs)
+	blueHourEnd, blueHourStart := GetAltitudeTimes(start, obs, -4)
+
+	want := []struct {
+		phase LightPhase
+		end   time.Time
+	}{
+		{NightPhase, times[NightEnd].Value},
+		{AstronomicalPhase, times[NauticalDawn].Value},
+		{NauticalPhase, times[Dawn].Value},
+		{CivilBlueHourPhase, blueHourEnd},
+		{CivilGoldenHourPhase, times[Sunrise].Value},
+		{DayGoldenHourPhase, times[GoldenHourEnd].Value},
+		{DayPhase, times[GoldenHour].Value},
+		{DayGoldenHourPhase, times[Sunset].Value},
+		{CivilGoldenHourPhase, blueHourStart},
+		{CivilBlueHourPhase, times[Dusk].Value},
+		{NauticalPhase, times[NauticalDusk].Value},
+		{AstronomicalPhase, times[Night].Value},
+		{NightPhase, end},
+	}
+
+	// the timeline follows the center of the sun, GetTimesWithObserver its upper edge 0.833° higher
+	got := GetTwilightTimeline(start, end, obs)
+	if len(got) != len(want) {
+		t.Fatalf("GetTwilightTimeline() = %v, want %v periods", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].Phase != w.phase || math.Abs(got[i].End.Sub(w.end).Seconds()) > 2 {
+			t.Errorf("GetTwilightTimeline() period %v = %v until %v, want %v until %v", i, got[i].Phase, got[i].End, w.phase, w.end)
+		}
+	}
+	if !got[0].Start.Equal(start) {
+		t.Errorf("GetTwilightTimeline() starts at %v, want %v", got[0].Start, start)
+	}
+}
+
+func TestGetTwilightTimelineContinuity(t *testing.T) {
+	// from noon to noon, the night crossing midnight is a single period
+	obs := Observer{Latitude: 48.85, Longitude: 2.35, Location: time.UTC}
+	start := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	got := GetTwilightTimeline(start, start.AddDate(0, 0, 1), obs)
+	if len(got) != 13 || got[6].Phase != NightPhase {
+		t.Fatalf("GetTwilightTimeline() = %v, want 13 periods with the night in the middle", got)
+	}
+	if midnight := start.Add(12 * time.Hour); got[6].Start.After(midnight) || got[6].End.Before(midnight) {
+		t.Errorf("GetTwilightTimeline() night = %v, want it to contain %v", got[6].Interval, midnight)
+	}
+	for i := 1; i < len(got); i++ {
+		if !got[i].Start.Equal(got[i-1].End) || got[i].Phase == got[i-1].Phase {
+			t.Errorf("GetTwilightTimeline() period %v does not follow %v", got[i], got[i-1])
+		}
+	}
+}
+
+func TestGetTwilightTimelinePolar(t *testing.T) {
+	tests := []struct {
+		name  string
+		obs   Observer
+		start time.Time
+		want  LightPhase
+	}{
+		{"polar day", Observer{Latitude: 78.22, Longitude: 15.65, Location: time.UTC}, time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), DayPhase},
+		{"polar night", Observer{Latitude: 89.9, Location: time.UTC}, time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC), NightPhase},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end := tt.start.AddDate(0, 0, 2)
+			got := GetTwilightTimeline(tt.start, end, tt.obs)
+			if len(got) != 1 || got[0].Phase != tt.want || !got[0].Start.Equal(tt.start) || !got[0].End.Equal(end) {
+				t.Errorf("GetTwilightTimeline() = %v, want a single %v period", got, tt.want)
+			}
+		})
+	}
+}
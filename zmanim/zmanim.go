@@ -0,0 +1,167 @@
+// Package zmanim calculates the times of the Jewish day (zmanim) from the position of the sun.
+//
+// Dawn and nightfall are defined by a depression angle of the sun or a fixed delay from
+// sunrise and sunset, the other times by temporal hours (shaot zmaniyot): twelfths of the
+// day, the day being bounded according to the chosen opinion.
+package zmanim
+
+import (
+	"time"
+
+	"github.com/sixdouglas/suncalc"
+)
+
+// Twilight is a time bounding the day: when the sun reaches an angle below the horizon, or a
+// fixed delay before sunrise or after sunset. The zero value is sunrise or sunset itself.
+type Twilight struct {
+	// Depression of the sun below the horizon, in degrees
+	Angle float64
+
+	// Delay before sunrise or after sunset, used instead of the angle when not zero
+	Offset time.Duration
+}
+
+// Opinion is a named set of definitions for the zmanim
+type Opinion struct {
+	Name string
+
+	AlotHashachar Twilight // dawn
+	Misheyakir    Twilight // earliest time to put on tallit and tefillin
+	Tzeit         Twilight // nightfall
+
+	// Bounds of the day divided into twelve temporal hours
+	DayStart Twilight
+	DayEnd   Twilight
+}
+
+// common opinions
+var (
+	// Vilna Gaon: temporal hours from sunrise to sunset
+	GRA = Opinion{
+		Name:          "GRA",
+		AlotHashachar: Twilight{Angle: 16.1},
+		Misheyakir:    Twilight{Angle: 11.5},
+		Tzeit:         Twilight{Angle: 8.5},
+	}
+
+	// Magen Avraham: temporal hours from 72 minutes before sunrise to 72 minutes after sunset
+	MGA = Opinion{
+		Name:          "MGA",
+		AlotHashachar: Twilight{Offset: 72 * time.Minute},
+		Misheyakir:    Twilight{Angle: 11.5},
+		Tzeit:         Twilight{Offset: 72 * time.Minute},
+		DayStart:      Twilight{Offset: 72 * time.Minute},
+		DayEnd:        Twilight{Offset: 72 * time.Minute},
+	}
+
+	// Magen Avraham, the day bounded by the sun 16.1 degrees below the horizon
+	MGA161 = Opinion{
+		Name:          "MGA 16.1°",
+		AlotHashachar: Twilight{Angle: 16.1},
+		Misheyakir:    Twilight{Angle: 10.2},
+		Tzeit:         Twilight{Angle: 16.1},
+		DayStart:      Twilight{Angle: 16.1},
+		DayEnd:        Twilight{Angle: 16.1},
+	}
+
+	// Rabbeinu Tam: nightfall 72 minutes after sunset, temporal hours from sunrise to sunset
+	RabbeinuTam = Opinion{
+		Name:          "Rabbeinu Tam",
+		AlotHashachar: Twilight{Angle: 16.1},
+		Misheyakir:    Twilight{Angle: 11.5},
+		Tzeit:         Twilight{Offset: 72 * time.Minute},
+	}
+)
+
+type Zmanim struct {
+	AlotHashachar time.Time
+	Misheyakir    time.Time
+	Sunrise       time.Time
+	SofZmanShema  time.Time // end of the third temporal hour
+	SofZmanTfila  time.Time // end of the fourth temporal hour
+	Chatzot       time.Time // solar noon
+	MinchaGedola  time.Time // six and a half temporal hours
+	MinchaKetana  time.Time // nine and a half temporal hours
+	PlagHamincha  time.Time // ten and three quarters temporal hours
+	Sunset        time.Time
+	Tzeit         time.Time
+
+	// Length of a temporal hour
+	ShaahZmanit time.Duration
+}
+
+// calculates the zmanim of the observer's local day according to the opinion. Sunrise and
+// sunset are adjusted to the observer height, so are the fixed delays relative to them,
+// while depression angles are measured from the mathematical horizon.
+func GetZmanim(date time.Time, obs suncalc.Observer, opinion Opinion) Zmanim {
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, obs.Location)
+	times := suncalc.GetTimesWithObserver(noon, obs)
+
+	d := day{noon, obs, times[suncalc.Sunrise].Value, times[suncalc.Sunset].Value}
+	result := Zmanim{
+		AlotHashachar: d.morning(opinion.AlotHashachar),
+		Misheyakir:    d.morning(opinion.Misheyakir),
+		Sunrise:       d.sunrise,
+		Chatzot:       times[suncalc.SolarNoon].Value,
+		Sunset:        d.sunset,
+		Tzeit:         d.evening(opinion.Tzeit),
+	}
+
+	start, end := d.morning(opinion.DayStart), d.evening(opinion.DayEnd)
+	if start.IsZero() || end.IsZero() {
+		return result
+	}
+
+	result.ShaahZmanit = end.Sub(start) / 12
+	hours := func(h float64) time.Time { return start.Add(time.Duration(h * float64(result.ShaahZmanit))) }
+	result.SofZmanShema = hours(3)
+	result.SofZmanTfila = hours(4)
+	result.MinchaGedola = hours(6.5)
+	result.MinchaKetana = hours(9.5)
+	result.PlagHamincha = hours(10.75)
+
+	return result
+}
+
+type day struct {
+	noon            time.Time
+	obs             suncalc.Observer
+	sunrise, sunset time.Time
+}
+
+func (d day) morning(t Twilight) time.Time {
+	switch {
+	case t.Offset != 0:
+		if d.sunrise.IsZero() {
+			return time.Time{}
+		}
+		return d.sunrise.Add(-t.Offset)
+	case t.Angle != 0:
+		morning, _ := suncalc.GetAltitudeTimes(d.noon, d.geometric(), -t.Angle)
+		return morning
+	default:
+		return d.sunrise
+	}
+}
+
+func (d day) evening(t Twilight) time.Time {
+	switch {
+	case t.Offset != 0:
+		if d.sunset.IsZero() {
+			return time.Time{}
+		}
+		return d.sunset.Add(t.Offset)
+	case t.Angle != 0:
+		_, evening := suncalc.GetAltitudeTimes(d.noon, d.geometric(), -t.Angle)
+		return evening
+	default:
+		return d.sunset
+	}
+}
+
+// the observer at sea level, depression angles being measured from the mathematical horizon
+func (d day) geometric() suncalc.Observer {
+	obs := d.obs
+	obs.Height = 0
+	return obs
+}
@@ -0,0 +1,97 @@
+package zmanim
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sixdouglas/suncalc"
+)
+
+var jerusalem = suncalc.Observer{Latitude: 31.778, Longitude: 35.235, Location: time.FixedZone("IDT", 3*60*60)}
+
+func TestGetZmanimOpinions(t *testing.T) {
+	date := time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC)
+	times := suncalc.GetTimesWithObserver(time.Date(2020, 5, 17, 12, 0, 0, 0, jerusalem.Location), jerusalem)
+	sunrise, sunset := times[suncalc.Sunrise].Value, times[suncalc.Sunset].Value
+
+	tests := []struct {
+		name     string
+		opinion  Opinion
+		dayStart time.Time
+		dayEnd   time.Time
+	}{
+		{"GRA", GRA, sunrise, sunset},
+		{"MGA", MGA, sunrise.Add(-72 * time.Minute), sunset.Add(72 * time.Minute)},
+		{"Rabbeinu Tam", RabbeinuTam, sunrise, sunset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetZmanim(date, jerusalem, tt.opinion)
+
+			hour := tt.dayEnd.Sub(tt.dayStart) / 12
+			if got.ShaahZmanit != hour {
+				t.Errorf("ShaahZmanit = %v, want %v", got.ShaahZmanit, hour)
+			}
+			if want := tt.dayStart.Add(3 * hour); !got.SofZmanShema.Equal(want) {
+				t.Errorf("SofZmanShema = %v, want %v", got.SofZmanShema, want)
+			}
+			if want := tt.dayStart.Add(4 * hour); !got.SofZmanTfila.Equal(want) {
+				t.Errorf("SofZmanTfila = %v, want %v", got.SofZmanTfila, want)
+			}
+			if want := tt.dayStart.Add(10*hour + 3*hour/4); !got.PlagHamincha.Equal(want) {
+				t.Errorf("PlagHamincha = %v, want %v", got.PlagHamincha, want)
+			}
+			// the sunrise and the sunset follow the declination of the sun, a few seconds from symmetric around the solar noon
+			if d := got.Chatzot.Sub(tt.dayStart.Add(6 * hour)); d < -15*time.Second || d > 15*time.Second {
+				t.Errorf("Chatzot = %v, want %v", got.Chatzot, tt.dayStart.Add(6*hour))
+			}
+
+			order := []time.Time{got.AlotHashachar, got.Misheyakir, got.Sunrise, got.SofZmanShema, got.SofZmanTfila,
+				got.Chatzot, got.MinchaGedola, got.MinchaKetana, got.PlagHamincha, got.Sunset, got.Tzeit}
+			for i := 1; i < len(order); i++ {
+				if !order[i].After(order[i-1]) {
+					t.Fatalf("GetZmanim() = %+v, times are not in order", got)
+				}
+			}
+		})
+	}
+
+	gra, mga := GetZmanim(date, jerusalem, GRA), GetZmanim(date, jerusalem, MGA)
+	if d := gra.SofZmanShema.Sub(mga.SofZmanShema); d != 36*time.Minute {
+		t.Errorf("MGA SofZmanShema %v before GRA, want 36m", d)
+	}
+	if d := sunrise.Sub(mga.AlotHashachar); d != 72*time.Minute {
+		t.Errorf("MGA AlotHashachar %v before sunrise, want 72m", d)
+	}
+	if alt := suncalc.GetPosition(gra.Tzeit, jerusalem.Latitude, jerusalem.Longitude).Altitude * 180 / math.Pi; math.Abs(alt+8.5) > 0.2 {
+		t.Errorf("GRA Tzeit at altitude %v, want -8.5", alt)
+	}
+}
+
+func TestGetZmanimElevation(t *testing.T) {
+	date := time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC)
+	high := jerusalem
+	high.Height = 800
+
+	sea, mountain := GetZmanim(date, jerusalem, GRA), GetZmanim(date, high, GRA)
+	if !mountain.Sunrise.Before(sea.Sunrise) || !mountain.Sunset.After(sea.Sunset) {
+		t.Errorf("GetZmanim() with elevation = %v / %v, want a longer day than %v / %v", mountain.Sunrise, mountain.Sunset, sea.Sunrise, sea.Sunset)
+	}
+	if !mountain.AlotHashachar.Equal(sea.AlotHashachar) || !mountain.Tzeit.Equal(sea.Tzeit) {
+		t.Errorf("GetZmanim() with elevation changed the depression angle times")
+	}
+}
+
+func TestGetZmanimHighLatitude(t *testing.T) {
+	// the sun stays above 16.1 degrees below the horizon in June in Oslo
+	oslo := suncalc.Observer{Latitude: 59.9139, Longitude: 10.7522, Location: time.FixedZone("CEST", 2*60*60)}
+	got := GetZmanim(time.Date(2020, 6, 21, 0, 0, 0, 0, time.UTC), oslo, MGA161)
+
+	if !got.AlotHashachar.IsZero() || !got.SofZmanShema.IsZero() || got.ShaahZmanit != 0 {
+		t.Errorf("GetZmanim() = %+v, want no dawn nor temporal hours", got)
+	}
+	if got.Sunrise.IsZero() || got.Chatzot.IsZero() {
+		t.Errorf("GetZmanim() = %+v, want a sunrise and a noon", got)
+	}
+}